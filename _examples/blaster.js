@@ -18,12 +18,23 @@ let blaster = wrpc.blaster({
   tags: { scenario: "contacts" },
 });
 
-export default function () {
+// built once and shared by every VU, open(file, "b") works too
+const payloads = wrpc.payloadPool("payloads", () => [new Uint8Array(1024), new Uint8Array(64 * 1024)]);
+
+export default async function () {
   // simple roundtrip
-  blaster.blast();
+  let res = blaster.blast();
+  console.log(`server: ${res.server_duration}ms, overhead: ${res.overhead}ms`);
+  console.log(`request: ${res.request_latency}ms, response: ${res.response_latency}ms`);
+  if (res.cold) {
+    console.log(`instance ${res.instance} started cold`);
+  }
+
+  // large packet, checking the component received it intact
+  blaster.blast({ payload_size: 1024 * 1024, verify: "digest" });
 
-  // large packet
-  blaster.blast({ payload: "x".repeat(1024 * 1024) });
+  // binary packet, picked from the shared pool
+  blaster.blast({ payload_pool: payloads });
 
   // all options
   blaster.blast({
@@ -38,4 +49,47 @@ export default function () {
     // set the request timeout to 10 seconds
     timeout_ms: 10000,
   });
+
+  // heavy-tailed cpu work and random payloads of about 64kb, sampled for every blast
+  blaster.blast({
+    cpu_burn_ms: { dist: "pareto", scale: 1, shape: 1.5, max: 1000 },
+    payload_size: { dist: "normal", mean: 64 * 1024, stddev: 8 * 1024 },
+  });
+
+  // host side wasi work: 1mb through the filesystem, 1mb of random bytes and 10k clock reads
+  let io = blaster.blast({ fs_burn_bytes: 1024 * 1024, random_burn_bytes: 1024 * 1024, clock_polls: 10000 });
+  console.log(`io: ${io.io_time}ms`);
+
+  // 3 outgoing http requests from the component, posting 1kb each
+  let outgoing = blaster.blast({ http_calls: 3, http_url: "http://localhost:8080/", http_body_size: 1024 });
+  for (const call of outgoing.http_calls) {
+    console.log(`outgoing: ${call.status} in ${call.duration}ms`);
+  }
+
+  // concurrent blasts from the same VU
+  let results = await Promise.all([blaster.blastAsync(), blaster.blastAsync()]);
+  console.log(`overheads: ${results.map((r) => r.overhead)}`);
+
+  // 1000 blasts pipelined from 50 goroutines
+  let summary = blaster.blastMany(1000, { concurrency: 50, packet: { cpu_burn_ms: 1 } });
+  console.log(`ok: ${summary.ok}, errors: ${summary.errors + summary.transport_errors}`);
+
+  // 500 blasts per second for 10 seconds, whatever the latency
+  let flooded = blaster.flood({ rate: 500, duration: "10s", max_in_flight: 200 });
+  console.log(`ok: ${flooded.ok}, dropped: ${flooded.dropped}`);
+
+  // chain the packet through 2 levels of 3 downstream blasters each
+  let chained = blaster.blast({ hops: 2, fanout: 3 });
+  for (const hop of chained.hops) {
+    console.log(`hop ${hop.depth}: ${hop.server_duration}ms`);
+  }
+
+  // stream 1mb up and down in 64kb chunks, needs a server exporting xk6:wrpc/blaster-stream
+  // blaster.blastStream({ upload_size: 1024 * 1024, download_size: 1024 * 1024, chunk_size: 64 * 1024 });
+
+  // fail 1% of the packets
+  let res2 = blaster.blast({ fail_probability: 0.01 });
+  if (res2.error) {
+    console.log(`component failed: ${res2.error}`);
+  }
 }
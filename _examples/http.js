@@ -13,7 +13,8 @@ export const options = {
 let http = wrpc.http({
   nats: {
     url: "nats://localhost:4222",
-    prefix: "default.AtVWn5-http_server",
+    // the http-echo component, see `wash get inventory` for its id
+    prefix: "default.http_echo_component-http_echo",
   },
   tags: { scenario: "contacts" },
 });
@@ -50,16 +51,44 @@ export default function () {
     // request headers
     headers: {
       "X-Header": "value",
+      // multi-valued header
+      Accept: ["text/plain", "application/json"],
+      // raw byte value
+      "X-Binary": new Uint8Array([0xca, 0xfe]).buffer,
     },
   });
 
-  // post with json body
+  // post with form body
   http.post("http://localhost:8000/post", { hello: "world" });
 
   // post with json body, returning body
   resp = http.post(
     "http://localhost:8000/post",
     { hello: "world" },
-    { consume: true },
+    { consume: true, json: true },
   );
+
+  // multipart file upload
+  http.post("http://localhost:8000/post", {
+    field: "value",
+    file: wrpc.file("hello world", "hello.txt", "text/plain"),
+  });
+
+  // per-request tags, and dynamic urls grouped under one `name` tag
+  for (let id = 0; id < 3; id++) {
+    http.get(wrpc.url`http://localhost:8000/anything/posts/${id}`, {
+      tags: { endpoint: "posts" },
+    });
+  }
+
+  // query string params
+  http.get("http://localhost:8000/get", { query: { page: 1, tags: ["a", "b"] } });
+
+  // the http-echo component routes mirror httpbin
+  http.get("http://localhost:8000/status/418");
+  http.get("http://localhost:8000/delay/100");
+  http.get("http://localhost:8000/redirect/3");
+  http.get("http://localhost:8000/stream-bytes/65536", {
+    query: { chunk_size: 1024 },
+  });
 }
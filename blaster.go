@@ -1,8 +1,14 @@
 package k6wrpc
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"hash/crc32"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 	"xk6-wrpc/internal/xk6/wrpc/blaster"
 
@@ -10,11 +16,127 @@ import (
 
 	"github.com/grafana/sobek"
 	"go.k6.io/k6/js/modules"
+	"go.k6.io/k6/js/promises"
 	"go.k6.io/k6/metrics"
 	wrpc "wrpc.io/go"
 )
 
-var DefaultBlasterTimeout = 10 * 1000
+var (
+	DefaultBlasterTimeout     = 10 * 1000
+	DefaultBlasterConcurrency = 10
+	// MaxBlasterPayloadSize bounds payload_size, samples of distributions without a max are clamped to it.
+	MaxBlasterPayloadSize = 16 * 1024 * 1024
+)
+
+// maxPacketRands bounds the RNGs a client keeps, scripts can pass a new seed on every iteration.
+const maxPacketRands = 64
+
+// blastResult is returned to JS by `blast()`. Durations are in milliseconds,
+// timestamps in milliseconds since the unix epoch as seen by the server.
+type blastResult struct {
+	ID                   string       `js:"id"`
+	Instance             string       `js:"instance"`
+	Invocation           uint64       `js:"invocation"`
+	Cold                 bool         `js:"cold"`
+	Duration             float64      `js:"duration"`
+	ServerDuration       float64      `js:"server_duration"`
+	Overhead             float64      `js:"overhead"`
+	RequestLatency       float64      `js:"request_latency"`
+	ResponseLatency      float64      `js:"response_latency"`
+	ClockOffset          float64      `js:"clock_offset"`
+	CPUTime              float64      `js:"cpu_time"`
+	IOTime               float64      `js:"io_time"`
+	ReceivedAt           float64      `js:"received_at"`
+	FinishedAt           float64      `js:"finished_at"`
+	MemoryAllocatedBytes uint64       `js:"memory_allocated_bytes"`
+	PayloadLen           uint64       `js:"payload_len"`
+	PayloadChecksum      uint32       `js:"payload_checksum"`
+	Hops                 []*hopResult `js:"hops"`
+	Corrupt              bool         `js:"corrupt"`
+	HTTPCalls            []*httpCall  `js:"http_calls"`
+	// set when the component returned an error, in which case only the id and duration are filled in
+	Error string `js:"error"`
+}
+
+// hopResult holds the server side timestamps of a downstream blaster the packet went through.
+type hopResult struct {
+	Depth          uint32  `js:"depth"`
+	ServerDuration float64 `js:"server_duration"`
+	ReceivedAt     float64 `js:"received_at"`
+	FinishedAt     float64 `js:"finished_at"`
+}
+
+// httpCall holds the timings of an outgoing HTTP request made by the component.
+type httpCall struct {
+	Status       uint16  `js:"status"`
+	ResponseSize uint64  `js:"response_size"`
+	Duration     float64 `js:"duration"`
+}
+
+// Payload verification modes.
+const (
+	// compare the length and CRC-32 checksum computed by the component
+	verifyDigest = "digest"
+	// compare the payload sent back by the component
+	verifyEcho = "echo"
+)
+
+// verifyPayload reports whether the component received the payload that was sent.
+func verifyPayload(mode string, sent []byte, res *blaster.Response) bool {
+	if mode == verifyEcho {
+		return bytes.Equal(sent, res.Payload)
+	}
+	return res.PayloadLen == uint64(len(sent)) && res.PayloadChecksum == crc32.ChecksumIEEE(sent)
+}
+
+func durationBetween(receivedAtNs, finishedAtNs uint64) time.Duration {
+	if finishedAtNs < receivedAtNs {
+		return 0
+	}
+	return time.Duration(finishedAtNs - receivedAtNs)
+}
+
+func newBlastResult(res *blaster.Response, duration time.Duration) *blastResult {
+	serverDuration := durationBetween(res.ReceivedAtNs, res.FinishedAtNs)
+
+	hops := make([]*hopResult, 0, len(res.Downstream))
+	for _, hop := range res.Downstream {
+		hops = append(hops, &hopResult{
+			Depth:          hop.Depth,
+			ServerDuration: metrics.D(durationBetween(hop.ReceivedAtNs, hop.FinishedAtNs)),
+			ReceivedAt:     float64(hop.ReceivedAtNs) / float64(time.Millisecond),
+			FinishedAt:     float64(hop.FinishedAtNs) / float64(time.Millisecond),
+		})
+	}
+
+	httpCalls := make([]*httpCall, 0, len(res.HttpCalls))
+	for _, call := range res.HttpCalls {
+		httpCalls = append(httpCalls, &httpCall{
+			Status:       call.Status,
+			ResponseSize: call.ResponseSize,
+			Duration:     metrics.D(time.Duration(call.DurationNs)),
+		})
+	}
+
+	return &blastResult{
+		ID:                   res.Id,
+		Instance:             res.InstanceId,
+		Invocation:           res.Invocation,
+		Cold:                 res.Invocation == 1,
+		Duration:             metrics.D(duration),
+		ServerDuration:       metrics.D(serverDuration),
+		Overhead:             metrics.D(duration - serverDuration),
+		CPUTime:              metrics.D(time.Duration(res.CpuTimeNs)),
+		IOTime:               metrics.D(time.Duration(res.IoTimeNs)),
+		ReceivedAt:           float64(res.ReceivedAtNs) / float64(time.Millisecond),
+		FinishedAt:           float64(res.FinishedAtNs) / float64(time.Millisecond),
+		MemoryAllocatedBytes: res.MemAllocatedBytes,
+		PayloadLen:           res.PayloadLen,
+		PayloadChecksum:      res.PayloadChecksum,
+		Hops:                 hops,
+		HTTPCalls:            httpCalls,
+	}
+}
 
 type wasiBlaster struct {
 	vu      modules.VU
@@ -22,12 +144,18 @@ type wasiBlaster struct {
 	metrics *wrpcMetrics
 	tags    map[string]string
 	invoker wrpc.Invoker
+	clock   clockFilter
+
+	// seed used by the calls that don't set one
+	seed uint64
+	// RNGs by seed, so each call samples the next values, at most maxPacketRands of them
+	rands map[uint64]*packetRand
 }
 
 func newBlaster(vu modules.VU, wm *wrpcMetrics, options clientOptions) (*wasiBlaster, error) {
 	rt := vu.Runtime()
 
-	driver, err := newNatsDriver(vu, wm, options.NATS, options.Tags)
+	driver, err := newNatsDriver(vu, wm, options)
 	if err != nil {
 		return nil, err
 	}
@@ -37,81 +165,333 @@ func newBlaster(vu modules.VU, wm *wrpcMetrics, options clientOptions) (*wasiBla
 		metrics: wm,
 		tags:    options.Tags,
 		obj:     rt.NewObject(),
-		invoker: driver.nc,
+		invoker: driver.invoker,
+		seed:    rand.Uint64(),
+		rands:   make(map[uint64]*packetRand),
 	}
 
 	if err := w.obj.Set("blast", w.doBlast); err != nil {
 		return nil, err
 	}
+	if err := w.obj.Set("blastAsync", w.doBlastAsync); err != nil {
+		return nil, err
+	}
+	if err := w.obj.Set("blastMany", w.doBlastMany); err != nil {
+		return nil, err
+	}
+	if err := w.obj.Set("flood", w.doFlood); err != nil {
+		return nil, err
+	}
+	if err := w.obj.Set("blastStream", w.doBlastStream); err != nil {
+		return nil, err
+	}
 
 	return w, nil
 }
 
-type blasterOptions struct {
-	CpuBurnMs    int
-	MemoryBurnMb int
-	WaitMs       int
-	Payload      string
-	TimeoutMs    int
-	Tags         map[string]string
+// blastCall is a blast prepared on the VU's event loop, it can then be invoked
+// any number of times from any goroutine.
+type blastCall struct {
+	ctx     context.Context
+	samples chan<- metrics.SampleContainer
+	packet  blaster.Packet
+	timeout time.Duration
+	tagSet  *metrics.TagSet
+
+	// sampled per invocation, nil when unset
+	cpuBurn     *distribution
+	memoryBurn  *distribution
+	wait        *distribution
+	payloadSize *distribution
+	payloadPool *payloadPool
+	rand        *packetRand
+	// how the payload received by the component is checked against the one sent, if at all
+	verify string
 }
 
-func (w *wasiBlaster) doBlast(options sobek.Value) error {
-	var tagSet *metrics.TagSet
-	if state := w.vu.State(); state == nil {
-		return fmt.Errorf("missing state blaster")
-	} else {
-		tagSet = state.Tags.GetCurrentValues().Tags.WithTagsFromMap(w.tags)
+func (w *wasiBlaster) newBlastCall(options sobek.Value) (*blastCall, error) {
+	state := w.vu.State()
+	if state == nil {
+		return nil, fmt.Errorf("missing state blaster")
 	}
 
-	timeout := DefaultBlasterTimeout
-	id, _ := uuid.NewV4()
-	packet := blaster.Packet{
-		Id: id.String(),
+	call := &blastCall{
+		ctx:     w.vu.Context(),
+		samples: state.Samples,
+		timeout: time.Duration(DefaultBlasterTimeout) * time.Millisecond,
+		tagSet:  state.Tags.GetCurrentValues().Tags.WithTagsFromMap(w.tags),
+	}
+	if options == nil {
+		return call, nil
 	}
 
-	reqStart := time.Now()
-
-	measurements := make([]metrics.Sample, 0)
-	defer func() {
-		w.metrics.pushIfNotDone(w.vu, measurements...)
-	}()
+	packet := &call.packet
+	d := newParamsDecoder(w.vu.Runtime(), "packet", options)
+	d.Distribution("cpu_burn_ms", &call.cpuBurn)
+	d.Distribution("memory_burn_mb", &call.memoryBurn)
+	d.Distribution("wait_ms", &call.wait)
+	d.Float("fail_probability", &packet.FailProbability)
+	if packet.FailProbability < 0 || packet.FailProbability > 1 {
+		d.Check("fail_probability", fmt.Errorf("expected a probability between 0 and 1, got %v", packet.FailProbability))
+	}
+	d.Bool("trap", &packet.Trap)
+	d.Bool("exceed_memory", &packet.ExceedMemory)
+	d.Uint32("hops", &packet.Hops)
+	d.Uint32("fanout", &packet.Fanout)
+	d.Uint("fs_burn_bytes", &packet.FsBurnBytes)
+	d.Uint("random_burn_bytes", &packet.RandomBurnBytes)
+	d.Uint("clock_polls", &packet.ClockPolls)
+	d.Uint32("http_calls", &packet.HttpCalls)
+	d.String("http_url", &packet.HttpUrl)
+	d.Uint("http_body_size", &packet.HttpBodySize)
+	if packet.HttpCalls > 0 && packet.HttpUrl == "" {
+		d.Check("http_url", fmt.Errorf("required by http_calls"))
+	}
 
-	rt := w.vu.Runtime()
-	if options != nil {
-		b := blasterOptions{Tags: make(map[string]string)}
-		if err := rt.ExportTo(options, &b); err != nil {
-			return err
+	d.Bytes("payload", &packet.Payload)
+	d.Distribution("payload_size", &call.payloadSize)
+	if call.payloadSize != nil {
+		if bound, ok := call.payloadSize.bound(); ok && bound > float64(MaxBlasterPayloadSize) {
+			d.Check("payload_size", fmt.Errorf("expected at most %d bytes, got %v", MaxBlasterPayloadSize, bound))
 		}
-		packet.CpuBurnMs = uint64(b.CpuBurnMs)
-		packet.MemBurnMb = uint64(b.MemoryBurnMb)
-		packet.WaitMs = uint64(b.WaitMs)
-		if b.Payload != "" {
-			packet.Payload = []byte(b.Payload)
+	}
+	if v, ok := d.lookup("payload_pool"); ok {
+		if call.payloadPool, ok = v.(*payloadPool); !ok {
+			d.Check("payload_pool", fmt.Errorf("expected a payload pool, got %T", v))
 		}
+	}
+	hasPayload := len(packet.Payload) > 0
+	if hasPayload && (call.payloadSize != nil || call.payloadPool != nil) || call.payloadSize != nil && call.payloadPool != nil {
+		d.Check("payload", fmt.Errorf("only one of payload, payload_size and payload_pool can be set"))
+	}
+
+	d.String("verify", &call.verify)
+	switch call.verify {
+	case "", verifyDigest:
+	case verifyEcho:
+		packet.Echo = true
+	default:
+		d.Check("verify", fmt.Errorf("expected %q or %q, got %q", verifyDigest, verifyEcho, call.verify))
+	}
+
+	seed := w.seed
+	d.Uint("seed", &seed)
+	call.rand = w.packetRand(seed, state.VUID)
+
+	// k6 duration string or ms, timeout_ms is kept for backwards compatibility
+	var timeoutMs int64
+	d.Int("timeout_ms", &timeoutMs)
+	if timeoutMs > 0 {
+		call.timeout = time.Duration(timeoutMs) * time.Millisecond
+	}
+	d.Duration("timeout", &call.timeout)
+
+	tags := make(map[string]string)
+	d.StringMap("tags", tags)
+	if len(tags) > 0 {
+		call.tagSet = call.tagSet.WithTagsFromMap(tags)
+	}
+
+	if err := d.Err(); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+// payloadLen samples the size of a random payload, capped at MaxBlasterPayloadSize.
+func (call *blastCall) payloadLen() uint64 {
+	return min(call.rand.Uint(call.payloadSize), uint64(MaxBlasterPayloadSize))
+}
 
-		if b.TimeoutMs > 0 {
-			timeout = b.TimeoutMs
+// packetRand returns the RNG of the client for seed, mixed with the VU id so VUs don't all sample the same values.
+// It is only called on the VU's event loop.
+func (w *wasiBlaster) packetRand(seed uint64, vuID uint64) *packetRand {
+	r, ok := w.rands[seed]
+	if !ok {
+		// evicted seeds start their sequence over if they are used again
+		if len(w.rands) >= maxPacketRands {
+			for evicted := range w.rands {
+				delete(w.rands, evicted)
+				break
+			}
 		}
+		r = newPacketRand(seed, vuID)
+		w.rands[seed] = r
+	}
+	return r
+}
 
-		if len(b.Tags) > 0 {
-			tagSet = tagSet.WithTagsFromMap(b.Tags)
+// invoke sends the packet with a fresh ID. Durations are measured from start,
+// which can be earlier than now when the call was scheduled for a given time.
+func (w *wasiBlaster) invoke(call *blastCall, start time.Time) (*blastResult, error) {
+	id, _ := uuid.NewV4()
+	packet := call.packet
+	packet.Id = id.String()
+	if call.rand != nil {
+		packet.CpuBurnMs = call.rand.Uint(call.cpuBurn)
+		packet.MemBurnMb = call.rand.Uint(call.memoryBurn)
+		packet.WaitMs = call.rand.Uint(call.wait)
+		if call.payloadSize != nil {
+			packet.Payload = call.rand.Bytes(call.payloadLen())
+		}
+		if call.payloadPool != nil {
+			packet.Payload = call.payloadPool.payloads[call.rand.IntN(len(call.payloadPool.payloads))]
 		}
 	}
+	tagSet := call.tagSet
+
+	measurements := make([]metrics.Sample, 0)
+	defer func() {
+		metrics.PushIfNotDone(call.ctx, call.samples, metrics.Samples(measurements))
+	}()
 
 	measurements = append(measurements, w.metrics.sample(w.metrics.blasterOperation, 1, tagSet))
 
-	ctx, done := context.WithTimeout(w.vu.Context(), time.Duration(timeout)*time.Millisecond)
+	ctx, done := context.WithTimeout(call.ctx, call.timeout)
 	defer done()
+	ctx = withInvocationScope(ctx, invocationScope{ctx: call.ctx, samples: call.samples, tagSet: tagSet})
 
-	err := blaster.Blast(ctx, w.invoker, &packet)
+	sentAt := time.Now()
+	packet.SentAtNs = uint64(sentAt.UnixNano())
+
+	// traps and components running out of memory can't answer, so they surface as transport errors
+	res, err := blaster.Blast(ctx, w.invoker, &packet)
+	answeredAt := time.Now()
 	if err != nil {
 		measurements = append(measurements, w.metrics.sample(w.metrics.blasterTransportError, 1, tagSet))
-		return err
+		return nil, err
+	}
+
+	if res.Err != nil {
+		result := &blastResult{
+			ID:       packet.Id,
+			Duration: metrics.D(time.Since(start)),
+			Error:    res.Err.Message,
+		}
+		measurements = append(measurements,
+			w.metrics.sample(w.metrics.blasterError, 1, tagSet),
+			w.metrics.sample(w.metrics.blasterDuration, result.Duration, tagSet),
+		)
+		return result, nil
+	}
+
+	result := newBlastResult(res.Ok, answeredAt.Sub(start))
+	if call.verify != "" && !verifyPayload(call.verify, packet.Payload, res.Ok) {
+		result.Corrupt = true
+		measurements = append(measurements, w.metrics.sample(w.metrics.blasterCorrupt, 1, tagSet))
+	}
+
+	receivedAt, finishedAt := time.Unix(0, int64(res.Ok.ReceivedAtNs)), time.Unix(0, int64(res.Ok.FinishedAtNs))
+	offset := w.clock.add(sentAt, receivedAt, finishedAt, answeredAt)
+	request, response := oneWayLatencies(sentAt, receivedAt, finishedAt, answeredAt, offset)
+	result.RequestLatency = metrics.D(request)
+	result.ResponseLatency = metrics.D(response)
+	result.ClockOffset = metrics.D(offset)
+	// the first packet handled by an instance pays for its cold start
+	tagSet = tagSet.With("instance", result.Instance).With("cold", strconv.FormatBool(result.Cold))
+	measurements = append(measurements,
+		w.metrics.sample(w.metrics.blasterDuration, result.Duration, tagSet),
+		w.metrics.sample(w.metrics.blasterServerDuration, result.ServerDuration, tagSet),
+		w.metrics.sample(w.metrics.blasterOverhead, result.Overhead, tagSet),
+		w.metrics.sample(w.metrics.blasterRequestLatency, result.RequestLatency, tagSet),
+		w.metrics.sample(w.metrics.blasterResponseLatency, result.ResponseLatency, tagSet),
+	)
+
+	return result, nil
+}
+
+func (w *wasiBlaster) doBlast(options sobek.Value) (*blastResult, error) {
+	call, err := w.newBlastCall(options)
+	if err != nil {
+		return nil, err
+	}
+	return w.invoke(call, time.Now())
+}
+
+// doBlastAsync sends the packet without blocking the VU.
+func (w *wasiBlaster) doBlastAsync(options sobek.Value) *sobek.Promise {
+	promise, resolve, reject := promises.New(w.vu)
+
+	call, err := w.newBlastCall(options)
+	if err != nil {
+		reject(err)
+		return promise
+	}
+
+	go func() {
+		result, err := w.invoke(call, time.Now())
+		if err != nil {
+			reject(err)
+			return
+		}
+		resolve(result)
+	}()
+
+	return promise
+}
+
+// blastManyResult is returned to JS by `blastMany()`, the duration is in milliseconds.
+type blastManyResult struct {
+	OK              int64   `js:"ok"`
+	Errors          int64   `js:"errors"`
+	TransportErrors int64   `js:"transport_errors"`
+	Duration        float64 `js:"duration"`
+}
+
+// doBlastMany pipelines n blasts over the same invoker from `concurrency` goroutines,
+// blocking until all of them are done.
+func (w *wasiBlaster) doBlastMany(n int64, options sobek.Value) (*blastManyResult, error) {
+	concurrency := int64(DefaultBlasterConcurrency)
+	var packetOptions sobek.Value
+	if options != nil {
+		d := newParamsDecoder(w.vu.Runtime(), "options", options)
+		d.Int("concurrency", &concurrency)
+		if concurrency <= 0 {
+			d.Check("concurrency", fmt.Errorf("expected a positive concurrency, got %d", concurrency))
+		}
+		packetOptions = d.Value("packet")
+		if err := d.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("invalid number of blasts %d", n)
+	}
+
+	call, err := w.newBlastCall(packetOptions)
+	if err != nil {
+		return nil, err
 	}
 
-	reqDuration := time.Since(reqStart)
-	measurements = append(measurements, w.metrics.sample(w.metrics.blasterDuration, metrics.D(reqDuration), tagSet))
+	var (
+		next                                 atomic.Int64
+		ok, componentErrors, transportErrors atomic.Int64
+		wg                                   sync.WaitGroup
+	)
+	start := time.Now()
+	for i := int64(0); i < min(concurrency, n); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for next.Add(1) <= n && call.ctx.Err() == nil {
+				result, err := w.invoke(call, time.Now())
+				switch {
+				case err != nil:
+					transportErrors.Add(1)
+				case result.Error != "":
+					componentErrors.Add(1)
+				default:
+					ok.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
 
-	return nil
+	return &blastManyResult{
+		OK:              ok.Load(),
+		Errors:          componentErrors.Load(),
+		TransportErrors: transportErrors.Load(),
+		Duration:        metrics.D(time.Since(start)),
+	}, nil
 }
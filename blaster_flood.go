@@ -0,0 +1,120 @@
+package k6wrpc
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/metrics"
+)
+
+var DefaultBlasterMaxInFlight = 1000
+
+// floodResult is returned to JS by `flood()`, the duration is in milliseconds.
+type floodResult struct {
+	OK              int64   `js:"ok"`
+	Errors          int64   `js:"errors"`
+	TransportErrors int64   `js:"transport_errors"`
+	Dropped         int64   `js:"dropped"`
+	Duration        float64 `js:"duration"`
+}
+
+// doFlood sends blasts at a fixed arrival rate for the given duration, regardless of how fast
+// the server answers. Latencies are measured from the time each blast was scheduled, so a slow
+// server can't hide its queueing delay by slowing the generator down. Blasts that would exceed
+// max_in_flight are dropped and counted instead of being sent late.
+func (w *wasiBlaster) doFlood(options sobek.Value) (*floodResult, error) {
+	var (
+		rate          float64
+		duration      time.Duration
+		maxInFlight   = int64(DefaultBlasterMaxInFlight)
+		packetOptions sobek.Value
+	)
+	if options != nil {
+		d := newParamsDecoder(w.vu.Runtime(), "options", options)
+		d.Float("rate", &rate)
+		d.Duration("duration", &duration)
+		d.Int("max_in_flight", &maxInFlight)
+		if maxInFlight <= 0 {
+			d.Check("max_in_flight", fmt.Errorf("expected a positive number of blasts, got %d", maxInFlight))
+		}
+		packetOptions = d.Value("packet")
+		if err := d.Err(); err != nil {
+			return nil, err
+		}
+	}
+	// rates above one blast per nanosecond truncate the interval to 0 and never reach the duration
+	interval := time.Duration(float64(time.Second) / rate)
+	if !(rate > 0) || interval <= 0 {
+		return nil, fmt.Errorf("invalid rate %v, expected a positive number of blasts per second up to %d", rate, time.Second)
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("invalid duration %v", duration)
+	}
+
+	call, err := w.newBlastCall(packetOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ok, componentErrors, transportErrors, dropped atomic.Int64
+		wg                                            sync.WaitGroup
+	)
+	inFlight := make(chan struct{}, maxInFlight)
+	start := time.Now()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for i := int64(0); ; i++ {
+		scheduled := start.Add(time.Duration(i) * interval)
+		if scheduled.Sub(start) >= duration {
+			break
+		}
+
+		timer.Reset(time.Until(scheduled))
+		select {
+		case <-call.ctx.Done():
+		case <-timer.C:
+		}
+		if call.ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case inFlight <- struct{}{}:
+		default:
+			dropped.Add(1)
+			metrics.PushIfNotDone(call.ctx, call.samples, w.metrics.sample(w.metrics.blasterDropped, 1, call.tagSet))
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-inFlight
+				wg.Done()
+			}()
+			result, err := w.invoke(call, scheduled)
+			switch {
+			case err != nil:
+				transportErrors.Add(1)
+			case result.Error != "":
+				componentErrors.Add(1)
+			default:
+				ok.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return &floodResult{
+		OK:              ok.Load(),
+		Errors:          componentErrors.Load(),
+		TransportErrors: transportErrors.Load(),
+		Dropped:         dropped.Load(),
+		Duration:        metrics.D(time.Since(start)),
+	}, nil
+}
@@ -0,0 +1,21 @@
+package k6wrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFloodRate(t *testing.T) {
+	t.Parallel()
+
+	runtime, w := getTestBlaster(t, 1)
+
+	for _, script := range []string{`({duration: "1s"})`, `({rate: -1, duration: "1s"})`, `({rate: 2e9, duration: "1s"})`, `({rate: Infinity, duration: "1s"})`} {
+		v, err := runtime.VU.Runtime().RunString(script)
+		require.NoError(t, err)
+		_, err = w.doFlood(v)
+		assert.ErrorContains(t, err, "rate", script)
+	}
+}
@@ -0,0 +1,152 @@
+package k6wrpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+	"xk6-wrpc/internal/xk6/wrpc/blaster_stream"
+
+	uuid "github.com/nu7hatch/gouuid"
+
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/metrics"
+)
+
+var DefaultBlasterChunkSize = 8 * 1024
+
+// MaxBlasterChunkSize bounds the chunk_size of a stream, the download is read into a buffer of that size.
+var MaxBlasterChunkSize = 1024 * 1024
+
+// streamResult is returned to JS by `blastStream()`, durations are in milliseconds.
+type streamResult struct {
+	ID         string  `js:"id"`
+	Duration   float64 `js:"duration"`
+	FirstByte  float64 `js:"first_byte"`
+	Uploaded   int64   `js:"uploaded"`
+	Downloaded int64   `js:"downloaded"`
+}
+
+// sizedReader streams size bytes of filler data, at most chunk bytes per read,
+// so uploads don't need to be held in memory.
+type sizedReader struct {
+	remaining int64
+	chunk     int64
+	read      int64
+}
+
+func (r *sizedReader) Read(b []byte) (int, error) {
+	if r.remaining <= 0 {
+		return 0, io.EOF
+	}
+	n := int64(len(b))
+	if r.chunk > 0 && n > r.chunk {
+		n = r.chunk
+	}
+	if n > r.remaining {
+		n = r.remaining
+	}
+	for i := range b[:n] {
+		b[i] = 'x'
+	}
+	r.remaining -= n
+	r.read += n
+	return int(n), nil
+}
+
+func (r *sizedReader) Close() error {
+	return nil
+}
+
+func (w *wasiBlaster) doBlastStream(options sobek.Value) (*streamResult, error) {
+	var tagSet *metrics.TagSet
+	if state := w.vu.State(); state == nil {
+		return nil, fmt.Errorf("missing state blaster")
+	} else {
+		tagSet = state.Tags.GetCurrentValues().Tags.WithTagsFromMap(w.tags)
+	}
+
+	timeout := time.Duration(DefaultBlasterTimeout) * time.Millisecond
+	id, _ := uuid.NewV4()
+	packet := blaster_stream.StreamPacket{
+		Id:        id.String(),
+		ChunkSize: uint64(DefaultBlasterChunkSize),
+	}
+	var uploadSize uint64
+
+	reqStart := time.Now()
+
+	measurements := make([]metrics.Sample, 0)
+	defer func() {
+		w.metrics.pushIfNotDone(w.vu, measurements...)
+	}()
+
+	if options != nil {
+		d := newParamsDecoder(w.vu.Runtime(), "packet", options)
+		d.Uint("upload_size", &uploadSize)
+		d.Uint("download_size", &packet.DownloadSize)
+		d.Uint("chunk_size", &packet.ChunkSize)
+		if packet.ChunkSize == 0 || packet.ChunkSize > uint64(MaxBlasterChunkSize) {
+			d.Check("chunk_size", fmt.Errorf("expected a chunk size between 1 and %d, got %d", MaxBlasterChunkSize, packet.ChunkSize))
+		}
+		d.Duration("timeout", &timeout)
+
+		tags := make(map[string]string)
+		d.StringMap("tags", tags)
+		if len(tags) > 0 {
+			tagSet = tagSet.WithTagsFromMap(tags)
+		}
+
+		if err := d.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	measurements = append(measurements, w.metrics.sample(w.metrics.blasterOperation, 1, tagSet))
+
+	ctx, done := context.WithTimeout(w.vu.Context(), timeout)
+	defer done()
+
+	upload := &sizedReader{
+		remaining: int64(uploadSize),
+		chunk:     int64(packet.ChunkSize),
+	}
+	ctx = withInvocationScope(ctx, invocationScope{ctx: w.vu.Context(), samples: w.vu.State().Samples, tagSet: tagSet})
+	download, writeErrs, err := blaster_stream.BlastStream(ctx, w.invoker, &packet, upload)
+	if err != nil {
+		measurements = append(measurements, w.metrics.sample(w.metrics.blasterTransportError, 1, tagSet))
+		return nil, err
+	}
+	defer download.Close()
+
+	result := &streamResult{ID: packet.Id}
+	buf := make([]byte, max(min(packet.ChunkSize, packet.DownloadSize), 1))
+	for {
+		n, err := download.Read(buf)
+		if n > 0 && result.Downloaded == 0 {
+			result.FirstByte = metrics.D(time.Since(reqStart))
+		}
+		result.Downloaded += int64(n)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			measurements = append(measurements, w.metrics.sample(w.metrics.blasterTransportError, 1, tagSet))
+			return nil, fmt.Errorf("failed to read download stream: %w", err)
+		}
+	}
+
+	if err := drainWriteErrs(writeErrs); err != nil {
+		measurements = append(measurements,
+			w.metrics.sample(w.metrics.blasterTransportError, 1, tagSet),
+			w.metrics.sample(w.metrics.writeError, 1, tagSet),
+		)
+		return nil, fmt.Errorf("failed to write upload stream: %w", err)
+	}
+
+	result.Uploaded = upload.read
+	result.Duration = metrics.D(time.Since(reqStart))
+	measurements = append(measurements, w.metrics.sample(w.metrics.blasterDuration, result.Duration, tagSet))
+
+	return result, nil
+}
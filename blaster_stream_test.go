@@ -0,0 +1,43 @@
+package k6wrpc
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizedReader(t *testing.T) {
+	t.Parallel()
+
+	r := &sizedReader{remaining: 10, chunk: 4}
+	buf := make([]byte, 8)
+
+	var reads []int
+	for {
+		n, err := r.Read(buf)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		reads = append(reads, n)
+	}
+
+	assert.Equal(t, []int{4, 4, 2}, reads)
+	assert.Equal(t, int64(10), r.read)
+	assert.Equal(t, "xxxx", string(buf[:4]))
+}
+
+func TestBlastStreamChunkSize(t *testing.T) {
+	t.Parallel()
+
+	runtime, w := getTestBlaster(t, 1)
+
+	for _, script := range []string{`({chunk_size: 0})`, `({chunk_size: 1024 * 1024 + 1})`} {
+		v, err := runtime.VU.Runtime().RunString(script)
+		require.NoError(t, err)
+		_, err = w.doBlastStream(v)
+		assert.ErrorContains(t, err, "chunk_size", script)
+	}
+}
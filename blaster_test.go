@@ -0,0 +1,137 @@
+package k6wrpc
+
+import (
+	"testing"
+	"time"
+	"xk6-wrpc/internal/xk6/wrpc/blaster"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBlastResult(t *testing.T) {
+	t.Parallel()
+
+	receivedAt := uint64(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano())
+	res := &blaster.Response{
+		Id:                "packet",
+		InstanceId:        "4f2a",
+		Invocation:        1,
+		ReceivedAtNs:      receivedAt,
+		FinishedAtNs:      receivedAt + uint64(30*time.Millisecond),
+		CpuTimeNs:         uint64(20 * time.Millisecond),
+		IoTimeNs:          uint64(5 * time.Millisecond),
+		MemAllocatedBytes: 1024,
+		PayloadLen:        5,
+		PayloadChecksum:   0x3610a686,
+		Downstream: []*blaster.Hop{
+			{Depth: 1, ReceivedAtNs: receivedAt + uint64(5*time.Millisecond), FinishedAtNs: receivedAt + uint64(25*time.Millisecond)},
+			{Depth: 2, ReceivedAtNs: receivedAt + uint64(10*time.Millisecond), FinishedAtNs: receivedAt + uint64(20*time.Millisecond)},
+		},
+		HttpCalls: []*blaster.HttpCall{
+			{Status: 200, ResponseSize: 1024, DurationNs: uint64(3 * time.Millisecond)},
+		},
+	}
+
+	result := newBlastResult(res, 50*time.Millisecond)
+	assert.Equal(t, "packet", result.ID)
+	assert.Equal(t, "4f2a", result.Instance)
+	assert.Equal(t, uint64(1), result.Invocation)
+	assert.True(t, result.Cold)
+	assert.Equal(t, 50.0, result.Duration)
+	assert.Equal(t, 30.0, result.ServerDuration)
+	assert.Equal(t, 20.0, result.Overhead)
+	assert.Equal(t, 20.0, result.CPUTime)
+	assert.Equal(t, 5.0, result.IOTime)
+	assert.Equal(t, float64(receivedAt)/1e6, result.ReceivedAt)
+	assert.Equal(t, uint64(1024), result.MemoryAllocatedBytes)
+	assert.Equal(t, uint64(5), result.PayloadLen)
+	assert.Equal(t, uint32(0x3610a686), result.PayloadChecksum)
+	require.Len(t, result.Hops, 2)
+	assert.Equal(t, uint32(1), result.Hops[0].Depth)
+	assert.Equal(t, 20.0, result.Hops[0].ServerDuration)
+	assert.Equal(t, uint32(2), result.Hops[1].Depth)
+	assert.Equal(t, 10.0, result.Hops[1].ServerDuration)
+	require.Len(t, result.HTTPCalls, 1)
+	assert.Equal(t, uint16(200), result.HTTPCalls[0].Status)
+	assert.Equal(t, uint64(1024), result.HTTPCalls[0].ResponseSize)
+	assert.Equal(t, 3.0, result.HTTPCalls[0].Duration)
+
+	// a server clock going backwards must not produce a negative server duration
+	res.FinishedAtNs = receivedAt - 1
+	result = newBlastResult(res, 50*time.Millisecond)
+	assert.Equal(t, 0.0, result.ServerDuration)
+	assert.Equal(t, 50.0, result.Overhead)
+}
+
+func TestVerifyPayload(t *testing.T) {
+	t.Parallel()
+
+	sent := []byte("hello")
+	res := &blaster.Response{PayloadLen: 5, PayloadChecksum: 0x3610a686, Payload: []byte("hello")}
+	assert.True(t, verifyPayload(verifyDigest, sent, res))
+	assert.True(t, verifyPayload(verifyEcho, sent, res))
+
+	assert.False(t, verifyPayload(verifyDigest, []byte("hellp"), res))
+	assert.False(t, verifyPayload(verifyDigest, []byte("hello!"), res))
+	assert.False(t, verifyPayload(verifyEcho, []byte("hellp"), res))
+
+	// an empty payload has a zero checksum
+	assert.True(t, verifyPayload(verifyDigest, nil, &blaster.Response{}))
+}
+
+func TestBlastCallPayloadSize(t *testing.T) {
+	t.Parallel()
+
+	runtime, w := getTestBlaster(t, 1)
+	newCall := func(script string) (*blastCall, error) {
+		v, err := runtime.VU.Runtime().RunString(script)
+		require.NoError(t, err)
+		return w.newBlastCall(v)
+	}
+
+	// a shape that small samples +Inf, it must not reach rand.Bytes
+	call, err := newCall(`({payload_size: {dist: "pareto", scale: 1, shape: 1e-9}})`)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, uint64(MaxBlasterPayloadSize), call.payloadLen())
+	}
+
+	_, err = newCall(`({payload_size: 1e12})`)
+	assert.ErrorContains(t, err, "payload_size")
+	_, err = newCall(`({payload_size: {dist: "exponential", mean: 1000, max: 1e12}})`)
+	assert.ErrorContains(t, err, "payload_size")
+}
+
+func TestBlastCallSeed(t *testing.T) {
+	t.Parallel()
+
+	newTestBlaster := func(vuID uint64) (*wasiBlaster, func() *blastCall) {
+		runtime, w := getTestBlaster(t, vuID)
+		return w, func() *blastCall {
+			v, err := runtime.VU.Runtime().RunString(`({cpu_burn_ms: {dist: "exponential", mean: 1000}, seed: 1})`)
+			require.NoError(t, err)
+			call, err := w.newBlastCall(v)
+			require.NoError(t, err)
+			return call
+		}
+	}
+
+	// every blast() with the same seed samples the next values rather than starting over
+	_, newCall := newTestBlaster(1)
+	first, second := newCall(), newCall()
+	assert.NotEqual(t, first.rand.Uint(first.cpuBurn), second.rand.Uint(second.cpuBurn))
+
+	// and VUs don't all sample the same values
+	_, newCall1 := newTestBlaster(1)
+	_, newCall2 := newTestBlaster(2)
+	call1, call2 := newCall1(), newCall2()
+	assert.NotEqual(t, call1.rand.Uint(call1.cpuBurn), call2.rand.Uint(call2.cpuBurn))
+
+	// a seed per iteration doesn't grow the client without bounds
+	w, _ := newTestBlaster(1)
+	for seed := uint64(0); seed < 2*maxPacketRands; seed++ {
+		w.packetRand(seed, 1)
+	}
+	assert.Len(t, w.rands, maxPacketRands)
+}
@@ -0,0 +1,138 @@
+package k6wrpc
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.k6.io/k6/js/common"
+	k6http "go.k6.io/k6/js/modules/k6/http"
+)
+
+const (
+	contentTypeForm = "application/x-www-form-urlencoded"
+	contentTypeJSON = "application/json"
+)
+
+// file mirrors k6/http's `http.file()`, so scripts don't need to import k6/http to upload files.
+func (mi *ModuleInstance) file(data interface{}, args ...string) k6http.FileData {
+	// supply valid default if filename and content-type are not specified
+	fname, ct := fmt.Sprintf("%d", time.Now().UnixNano()), "application/octet-stream"
+
+	if len(args) > 0 {
+		fname = args[0]
+
+		if len(args) > 1 {
+			ct = args[1]
+		}
+	}
+
+	dt, err := common.ToBytes(data)
+	if err != nil {
+		common.Throw(mi.vu.Runtime(), err)
+	}
+
+	return k6http.FileData{
+		Data:        dt,
+		Filename:    fname,
+		ContentType: ct,
+	}
+}
+
+func formatFormValue(v interface{}) string {
+	return fmt.Sprintf("%v", v)
+}
+
+func formValues(data map[string]interface{}) url.Values {
+	values := make(url.Values, len(data))
+	for k, v := range data {
+		if arr, ok := v.([]interface{}); ok {
+			for _, el := range arr {
+				values.Add(k, formatFormValue(el))
+			}
+			continue
+		}
+		values.Set(k, formatFormValue(v))
+	}
+	return values
+}
+
+func containsFile(data map[string]interface{}) bool {
+	for _, v := range data {
+		if _, ok := v.(k6http.FileData); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeObjectBody encodes a JS object the same way k6/http does:
+// `application/x-www-form-urlencoded` unless one of the values is a file,
+// in which case the body becomes `multipart/form-data`.
+func encodeObjectBody(data map[string]interface{}) ([]byte, string, error) {
+	if !containsFile(data) {
+		return []byte(formValues(data).Encode()), contentTypeForm, nil
+	}
+
+	var buf bytes.Buffer
+	mpw := multipart.NewWriter(&buf)
+	for k, v := range data {
+		switch ve := v.(type) {
+		case k6http.FileData:
+			h := make(textproto.MIMEHeader)
+			h.Set("Content-Disposition",
+				fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+					escapeQuotes(k), escapeQuotes(ve.Filename)))
+			h.Set("Content-Type", ve.ContentType)
+
+			fw, err := mpw.CreatePart(h)
+			if err != nil {
+				return nil, "", err
+			}
+			if _, err := fw.Write(ve.Data); err != nil {
+				return nil, "", err
+			}
+		default:
+			fw, err := mpw.CreateFormField(k)
+			if err != nil {
+				return nil, "", err
+			}
+			if _, err := fw.Write([]byte(formatFormValue(v))); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	if err := mpw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), mpw.FormDataContentType(), nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// withQuery merges the `query` request param into the url query string.
+func withQuery(u *url.URL, query map[string]interface{}) *url.URL {
+	if len(query) == 0 {
+		return u
+	}
+
+	q := u.Query()
+	for k, vs := range formValues(query) {
+		for _, v := range vs {
+			q.Add(k, v)
+		}
+	}
+
+	merged := *u
+	merged.RawQuery = q.Encode()
+	return &merged
+}
@@ -0,0 +1,91 @@
+package k6wrpc
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k6http "go.k6.io/k6/js/modules/k6/http"
+)
+
+func TestJSBodyToWrpc(t *testing.T) {
+	t.Parallel()
+
+	body, contentType, err := jsBodyToWrpc(map[string]interface{}{
+		"hello": "world",
+		"list":  []interface{}{int64(1), int64(2)},
+	}, false)
+	require.NoError(t, err)
+	assert.Equal(t, contentTypeForm, contentType)
+	assert.Equal(t, "hello=world&list=1&list=2", string(body))
+
+	body, contentType, err = jsBodyToWrpc(map[string]interface{}{"hello": "world"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, contentTypeJSON, contentType)
+	assert.Equal(t, `{"hello":"world"}`, string(body))
+
+	body, contentType, err = jsBodyToWrpc("raw", false)
+	require.NoError(t, err)
+	assert.Empty(t, contentType)
+	assert.Equal(t, "raw", string(body))
+}
+
+func TestMultipartBody(t *testing.T) {
+	t.Parallel()
+
+	runtime, _ := getTestModuleInstance(t)
+	v, err := runtime.VU.Runtime().RunString(`http.file("hello world", "hello.txt", "text/plain")`)
+	require.NoError(t, err)
+
+	body, contentType, err := jsBodyToWrpc(map[string]interface{}{
+		"field": "value",
+		"file":  v.Export(),
+	}, false)
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/form-data", mediaType)
+
+	parts := make(map[string]*multipart.Part)
+	contents := make(map[string]string)
+	mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(part)
+		require.NoError(t, err)
+		parts[part.FormName()] = part
+		contents[part.FormName()] = string(data)
+	}
+
+	require.Len(t, parts, 2)
+	assert.Equal(t, "value", contents["field"])
+	assert.Equal(t, "hello world", contents["file"])
+	assert.Equal(t, "hello.txt", parts["file"].FileName())
+	assert.Equal(t, "text/plain", parts["file"].Header.Get("Content-Type"))
+	assert.IsType(t, k6http.FileData{}, v.Export())
+}
+
+func TestWithQuery(t *testing.T) {
+	t.Parallel()
+
+	u, err := url.Parse("http://localhost/anything?a=1")
+	require.NoError(t, err)
+
+	merged := withQuery(u, map[string]interface{}{
+		"b": "2",
+		"c": []interface{}{"3", "4"},
+	})
+	assert.Equal(t, "/anything?a=1&b=2&c=3&c=4", merged.RequestURI())
+	// the original url is left untouched
+	assert.Equal(t, "a=1", u.RawQuery)
+}
@@ -0,0 +1,56 @@
+package k6wrpc
+
+import (
+	"sync"
+	"time"
+)
+
+// clockFilterSize is the number of recent exchanges the clock offset is estimated from.
+const clockFilterSize = 8
+
+// clockSample is the result of a single exchange: the server clock offset it implies and how much to trust it.
+type clockSample struct {
+	offset time.Duration
+	delay  time.Duration
+}
+
+// clockFilter estimates how far the server's clock is ahead of ours NTP-style, trusting the exchange with
+// the lowest round trip delay among the recent ones, as its offset is the least skewed by asymmetric delays.
+type clockFilter struct {
+	mu      sync.Mutex
+	samples [clockFilterSize]clockSample
+	next    int
+	count   int
+}
+
+// add records an exchange sent at t0 and answered at t3 by our clock, received at t1 and answered
+// at t2 by the server's, returning the current offset estimate.
+func (f *clockFilter) add(t0, t1, t2, t3 time.Time) time.Duration {
+	sample := clockSample{
+		offset: (t1.Sub(t0) + t2.Sub(t3)) / 2,
+		delay:  max(t3.Sub(t0)-t2.Sub(t1), 0),
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.samples[f.next] = sample
+	f.next = (f.next + 1) % clockFilterSize
+	f.count = min(f.count+1, clockFilterSize)
+
+	best := f.samples[0]
+	for _, s := range f.samples[1:f.count] {
+		if s.delay < best.delay {
+			best = s
+		}
+	}
+	return best.offset
+}
+
+// oneWayLatencies splits the round trip of an exchange given the server clock offset.
+// Both latencies are clamped to 0 as the estimate can be off by up to half the best round trip delay.
+func oneWayLatencies(t0, t1, t2, t3 time.Time, offset time.Duration) (request, response time.Duration) {
+	request = max(t1.Sub(t0)-offset, 0)
+	response = max(t3.Sub(t2)+offset, 0)
+	return request, response
+}
@@ -0,0 +1,54 @@
+package k6wrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClockFilter(t *testing.T) {
+	t.Parallel()
+
+	// the server clock is 1s ahead, requests take 10ms and responses 30ms
+	const offset = time.Second
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	exchange := func(sent time.Time, request, work, response time.Duration) (t0, t1, t2, t3 time.Time) {
+		t0 = sent
+		t1 = t0.Add(request + offset)
+		t2 = t1.Add(work)
+		t3 = t2.Add(response - offset)
+		return t0, t1, t2, t3
+	}
+
+	var f clockFilter
+	t0, t1, t2, t3 := exchange(base, 10*time.Millisecond, 5*time.Millisecond, 30*time.Millisecond)
+	// a single exchange can't tell asymmetric delays from an offset
+	estimate := f.add(t0, t1, t2, t3)
+	assert.Equal(t, offset-10*time.Millisecond, estimate)
+
+	// the exchange with the lowest delay wins
+	t0, t1, t2, t3 = exchange(base.Add(time.Second), 2*time.Millisecond, 5*time.Millisecond, 2*time.Millisecond)
+	estimate = f.add(t0, t1, t2, t3)
+	assert.Equal(t, offset, estimate)
+
+	t0, t1, t2, t3 = exchange(base.Add(2*time.Second), 10*time.Millisecond, 5*time.Millisecond, 30*time.Millisecond)
+	estimate = f.add(t0, t1, t2, t3)
+	assert.Equal(t, offset, estimate)
+
+	request, response := oneWayLatencies(t0, t1, t2, t3, estimate)
+	assert.Equal(t, 10*time.Millisecond, request)
+	assert.Equal(t, 30*time.Millisecond, response)
+
+	// the best exchange ages out of the filter
+	for i := range clockFilterSize {
+		t0, t1, t2, t3 = exchange(base.Add(time.Duration(3+i)*time.Second), 10*time.Millisecond, 5*time.Millisecond, 30*time.Millisecond)
+		estimate = f.add(t0, t1, t2, t3)
+	}
+	assert.Equal(t, offset-10*time.Millisecond, estimate)
+
+	// latencies never go negative
+	request, response = oneWayLatencies(t0, t1, t2, t3, 10*time.Second)
+	assert.Zero(t, request)
+	assert.Equal(t, 10*time.Second+30*time.Millisecond-offset, response)
+}
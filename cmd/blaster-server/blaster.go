@@ -0,0 +1,319 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	mathrand "math/rand/v2"
+	"net/http"
+	"os"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	wrpc "wrpc.io/go"
+
+	"xk6-wrpc/cmd/blaster-server/bindings/exports/xk6/wrpc/blaster"
+	client "xk6-wrpc/internal/xk6/wrpc/blaster"
+)
+
+// ioChunkSize bounds the buffers used for filesystem, random and http work.
+const ioChunkSize = 64 * 1024
+
+// blasterHandler handles packets like the blaster component does, natively.
+type blasterHandler struct {
+	// invokes the downstream blasters when chaining packets, nil if there are none
+	invoker wrpc.Invoker
+	// directory used by fs_burn_bytes
+	dir         string
+	instanceID  string
+	invocations atomic.Uint64
+	http        *http.Client
+}
+
+func newBlaster(invoker wrpc.Invoker, dir string) *blasterHandler {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return &blasterHandler{
+		invoker:    invoker,
+		dir:        dir,
+		instanceID: hex.EncodeToString(id),
+		http:       &http.Client{},
+	}
+}
+
+type blastResult = wrpc.Result[blaster.Response, blaster.Error]
+
+func (b *blasterHandler) Blast(ctx context.Context, pkt *blaster.Packet) (*blastResult, error) {
+	// Faults that kill a component instance fail the invocation, which clients see as a transport error
+	if pkt.Trap {
+		return nil, errors.New("trap requested by packet " + pkt.Id)
+	}
+	if pkt.ExceedMemory {
+		return nil, errors.New("exceeding memory requested by packet " + pkt.Id)
+	}
+	if pkt.FailProbability > 0 && mathrand.Float64() < pkt.FailProbability {
+		return wrpc.Err[blaster.Response](blaster.Error{
+			Id:      pkt.Id,
+			Message: "injected failure",
+		}), nil
+	}
+
+	res, blastErr := b.work(ctx, pkt)
+	if blastErr != nil {
+		return wrpc.Err[blaster.Response](*blastErr), nil
+	}
+
+	// Chain the packet through the downstream blasters
+	if pkt.Hops > 0 {
+		downstream, blastErr := b.forward(ctx, pkt)
+		if blastErr != nil {
+			return wrpc.Err[blaster.Response](*blastErr), nil
+		}
+		res.Downstream = downstream
+		res.FinishedAtNs = uint64(time.Now().UnixNano())
+	}
+
+	return wrpc.Ok[blaster.Error](*res), nil
+}
+
+// forward invokes `fanout` downstream blasters with one less hop, returning
+// every hop the packet went through below this one.
+func (b *blasterHandler) forward(ctx context.Context, pkt *blaster.Packet) ([]*blaster.Hop, *blaster.Error) {
+	if b.invoker == nil {
+		return nil, &blaster.Error{
+			Id:      pkt.Id,
+			Message: "downstream: no downstream prefix configured",
+		}
+	}
+
+	next := toClientPacket(pkt)
+	next.Hops--
+	next.SentAtNs = uint64(time.Now().UnixNano())
+
+	fanout := max(pkt.Fanout, 1)
+
+	var hops []*blaster.Hop
+	for i := uint32(0); i < fanout; i++ {
+		result, err := client.Blast(ctx, b.invoker, next)
+		if err != nil {
+			return nil, &blaster.Error{
+				Id:      pkt.Id,
+				Message: "downstream: " + err.Error(),
+			}
+		}
+		if result.Err != nil {
+			return nil, &blaster.Error{
+				Id:      pkt.Id,
+				Message: "downstream: " + result.Err.Message,
+			}
+		}
+
+		hops = append(hops, &blaster.Hop{
+			Depth:        1,
+			ReceivedAtNs: result.Ok.ReceivedAtNs,
+			FinishedAtNs: result.Ok.FinishedAtNs,
+		})
+		for _, hop := range result.Ok.Downstream {
+			hops = append(hops, &blaster.Hop{
+				Depth:        hop.Depth + 1,
+				ReceivedAtNs: hop.ReceivedAtNs,
+				FinishedAtNs: hop.FinishedAtNs,
+			})
+		}
+	}
+
+	return hops, nil
+}
+
+// toClientPacket converts an exported packet to the imported one, the bindings declare their own types.
+func toClientPacket(pkt *blaster.Packet) *client.Packet {
+	return &client.Packet{
+		Id:              pkt.Id,
+		Payload:         pkt.Payload,
+		SentAtNs:        pkt.SentAtNs,
+		Echo:            pkt.Echo,
+		MemBurnMb:       pkt.MemBurnMb,
+		CpuBurnMs:       pkt.CpuBurnMs,
+		WaitMs:          pkt.WaitMs,
+		FailProbability: pkt.FailProbability,
+		Trap:            pkt.Trap,
+		ExceedMemory:    pkt.ExceedMemory,
+		Hops:            pkt.Hops,
+		Fanout:          pkt.Fanout,
+		FsBurnBytes:     pkt.FsBurnBytes,
+		RandomBurnBytes: pkt.RandomBurnBytes,
+		ClockPolls:      pkt.ClockPolls,
+		HttpCalls:       pkt.HttpCalls,
+		HttpUrl:         pkt.HttpUrl,
+		HttpBodySize:    pkt.HttpBodySize,
+	}
+}
+
+func (b *blasterHandler) work(ctx context.Context, pkt *blaster.Packet) (*blaster.Response, *blaster.Error) {
+	res := &blaster.Response{
+		Id:              pkt.Id,
+		ReceivedAtNs:    uint64(time.Now().UnixNano()),
+		InstanceId:      b.instanceID,
+		Invocation:      b.invocations.Add(1),
+		PayloadLen:      uint64(len(pkt.Payload)),
+		PayloadChecksum: crc32.ChecksumIEEE(pkt.Payload),
+	}
+	if pkt.Echo {
+		res.Payload = pkt.Payload
+	}
+
+	// Allocate & hold memory during each invocation, touching the pages so they are actually committed
+	var mem []byte
+	if pkt.MemBurnMb > 0 {
+		mem = make([]byte, pkt.MemBurnMb*1024*1024)
+		for i := 0; i < len(mem); i += os.Getpagesize() {
+			mem[i] = 1
+		}
+		res.MemAllocatedBytes = uint64(len(mem))
+	}
+
+	// Simple sleep
+	if pkt.WaitMs > 0 {
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Duration(pkt.WaitMs) * time.Millisecond):
+		}
+	}
+
+	// Spin CPU
+	if pkt.CpuBurnMs > 0 {
+		start := time.Now()
+		for time.Since(start) < time.Duration(pkt.CpuBurnMs)*time.Millisecond {
+		}
+		res.CpuTimeNs = uint64(time.Since(start).Nanoseconds())
+	}
+
+	// Exercise the OS, like the component exercises the host's WASI implementations
+	if pkt.FsBurnBytes > 0 || pkt.RandomBurnBytes > 0 || pkt.ClockPolls > 0 {
+		start := time.Now()
+		if pkt.FsBurnBytes > 0 {
+			if err := burnFS(b.dir, pkt.Id, pkt.FsBurnBytes); err != nil {
+				return nil, &blaster.Error{
+					Id:      pkt.Id,
+					Message: "fs: " + err.Error(),
+				}
+			}
+		}
+		burnRandom(pkt.RandomBurnBytes)
+		burnClock(pkt.ClockPolls)
+		res.IoTimeNs = uint64(time.Since(start).Nanoseconds())
+	}
+
+	// Call the outgoing HTTP dependency
+	for i := uint32(0); i < pkt.HttpCalls; i++ {
+		call, err := b.callHTTP(ctx, pkt.HttpUrl, pkt.HttpBodySize)
+		if err != nil {
+			return nil, &blaster.Error{
+				Id:      pkt.Id,
+				Message: "http: " + err.Error(),
+			}
+		}
+		res.HttpCalls = append(res.HttpCalls, call)
+	}
+
+	// keep the allocation alive until we are done
+	runtime.KeepAlive(mem)
+	res.FinishedAtNs = uint64(time.Now().UnixNano())
+
+	return res, nil
+}
+
+// burnFS writes size bytes to a scratch file in dir, syncs it and reads it back.
+func burnFS(dir string, id string, size uint64) error {
+	file, err := os.CreateTemp(dir, "blaster-"+id+"-")
+	if err != nil {
+		return fmt.Errorf("open: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	chunk := bytes.Repeat([]byte{'x'}, int(min(size, ioChunkSize)))
+	for written := uint64(0); written < size; {
+		n, err := file.Write(chunk[:min(size-written, ioChunkSize)])
+		if err != nil {
+			return fmt.Errorf("write: %w", err)
+		}
+		written += uint64(n)
+	}
+
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("sync: %w", err)
+	}
+
+	if _, err := io.CopyBuffer(io.Discard, io.NewSectionReader(file, 0, int64(size)), chunk); err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+
+	return nil
+}
+
+// burnRandom reads size bytes from the OS's secure random source.
+func burnRandom(size uint64) {
+	buf := make([]byte, min(size, ioChunkSize))
+	for size > 0 {
+		n := min(size, ioChunkSize)
+		_, _ = rand.Read(buf[:n])
+		size -= n
+	}
+}
+
+// burnClock reads the monotonic clock polls times.
+func burnClock(polls uint64) {
+	for i := uint64(0); i < polls; i++ {
+		_ = time.Now()
+	}
+}
+
+// callHTTP sends a request to url, POSTing bodySize bytes if set, and reads the whole response.
+func (b *blasterHandler) callHTTP(ctx context.Context, url string, bodySize uint64) (*blaster.HttpCall, error) {
+	start := time.Now()
+
+	method := http.MethodGet
+	var body io.Reader
+	if bodySize > 0 {
+		method = http.MethodPost
+		body = io.LimitReader(filler{}, int64(bodySize))
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+
+	resp, err := b.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	size, err := io.Copy(io.Discard, resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("response body: %w", err)
+	}
+
+	return &blaster.HttpCall{
+		Status:       uint16(resp.StatusCode),
+		ResponseSize: uint64(size),
+		DurationNs:   uint64(time.Since(start).Nanoseconds()),
+	}, nil
+}
+
+// filler is an endless stream of 'x', bodies and downloads don't need to be held in memory.
+type filler struct{}
+
+func (filler) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = 'x'
+	}
+	return len(b), nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"xk6-wrpc/cmd/blaster-server/bindings/exports/xk6/wrpc/blaster_stream"
+)
+
+// defaultChunkSize matches the client's default chunk size.
+const defaultChunkSize = 8 * 1024
+
+type blasterStream struct{}
+
+// BlastStream discards the upload and streams download_size bytes back in chunk_size chunks.
+func (s *blasterStream) BlastStream(ctx context.Context, pkt *blaster_stream.StreamPacket, upload io.ReadCloser) (io.ReadCloser, error) {
+	go func() {
+		defer upload.Close()
+		if _, err := io.Copy(io.Discard, upload); err != nil {
+			slog.WarnContext(ctx, "failed to read upload stream", "id", pkt.Id, "err", err)
+		}
+	}()
+
+	chunk := pkt.ChunkSize
+	if chunk == 0 {
+		chunk = defaultChunkSize
+	}
+	return io.NopCloser(&chunkReader{
+		r:     io.LimitReader(filler{}, int64(pkt.DownloadSize)),
+		chunk: int(chunk),
+	}), nil
+}
+
+// chunkReader reads at most chunk bytes at a time, so downloads are streamed in chunks of that size.
+type chunkReader struct {
+	r     io.Reader
+	chunk int
+}
+
+func (r *chunkReader) Read(b []byte) (int, error) {
+	if len(b) > r.chunk {
+		b = b[:r.chunk]
+	}
+	return r.r.Read(b)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"xk6-wrpc/cmd/blaster-server/bindings/exports/xk6/wrpc/blaster"
+	"xk6-wrpc/cmd/blaster-server/bindings/exports/xk6/wrpc/blaster_stream"
+)
+
+func TestBlast(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(w, r.Body)
+	}))
+	defer srv.Close()
+
+	b := newBlaster(nil, t.TempDir())
+	payload := []byte("hello")
+	result, err := b.Blast(context.Background(), &blaster.Packet{
+		Id:              "1",
+		Payload:         payload,
+		Echo:            true,
+		MemBurnMb:       1,
+		CpuBurnMs:       5,
+		FsBurnBytes:     100 * 1024,
+		RandomBurnBytes: 1024,
+		ClockPolls:      10,
+		HttpCalls:       2,
+		HttpUrl:         srv.URL,
+		HttpBodySize:    16,
+	})
+	require.NoError(t, err)
+	require.Nil(t, result.Err)
+
+	res := result.Ok
+	assert.Equal(t, "1", res.Id)
+	assert.Equal(t, b.instanceID, res.InstanceId)
+	assert.Equal(t, uint64(1), res.Invocation)
+	assert.Equal(t, payload, res.Payload)
+	assert.Equal(t, uint64(len(payload)), res.PayloadLen)
+	assert.Equal(t, crc32.ChecksumIEEE(payload), res.PayloadChecksum)
+	assert.Equal(t, uint64(1024*1024), res.MemAllocatedBytes)
+	assert.GreaterOrEqual(t, res.CpuTimeNs, uint64(5*time.Millisecond))
+	assert.NotZero(t, res.IoTimeNs)
+	assert.GreaterOrEqual(t, res.FinishedAtNs, res.ReceivedAtNs)
+	require.Len(t, res.HttpCalls, 2)
+	assert.Equal(t, uint16(http.StatusOK), res.HttpCalls[0].Status)
+	assert.Equal(t, uint64(16), res.HttpCalls[0].ResponseSize)
+
+	result, err = b.Blast(context.Background(), &blaster.Packet{Id: "2"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), result.Ok.Invocation)
+}
+
+func TestBlastFaults(t *testing.T) {
+	t.Parallel()
+
+	b := newBlaster(nil, t.TempDir())
+
+	result, err := b.Blast(context.Background(), &blaster.Packet{Id: "1", FailProbability: 1})
+	require.NoError(t, err)
+	require.NotNil(t, result.Err)
+	assert.Equal(t, "1", result.Err.Id)
+	assert.Equal(t, "injected failure", result.Err.Message)
+
+	_, err = b.Blast(context.Background(), &blaster.Packet{Id: "2", Trap: true})
+	assert.ErrorContains(t, err, "trap requested by packet 2")
+
+	_, err = b.Blast(context.Background(), &blaster.Packet{Id: "3", ExceedMemory: true})
+	assert.ErrorContains(t, err, "exceeding memory requested by packet 3")
+
+	result, err = b.Blast(context.Background(), &blaster.Packet{Id: "4", HttpCalls: 1, HttpUrl: "http://[::1"})
+	require.NoError(t, err)
+	require.NotNil(t, result.Err)
+	assert.Contains(t, result.Err.Message, "http: request")
+
+	result, err = b.Blast(context.Background(), &blaster.Packet{Id: "5", Hops: 1})
+	require.NoError(t, err)
+	require.NotNil(t, result.Err)
+	assert.Equal(t, "downstream: no downstream prefix configured", result.Err.Message)
+}
+
+func TestBlastStream(t *testing.T) {
+	t.Parallel()
+
+	s := &blasterStream{}
+	download, err := s.BlastStream(context.Background(), &blaster_stream.StreamPacket{
+		Id:           "1",
+		DownloadSize: 20000,
+		ChunkSize:    1000,
+	}, io.NopCloser(io.LimitReader(filler{}, 5000)))
+	require.NoError(t, err)
+
+	buf := make([]byte, 4096)
+	n, err := download.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 1000, n)
+
+	rest, err := io.ReadAll(download)
+	require.NoError(t, err)
+	assert.Len(t, rest, 19000)
+}
@@ -0,0 +1,62 @@
+// blaster-server serves `xk6:wrpc/blaster` and `xk6:wrpc/blaster-stream` natively over NATS,
+// honoring the same packets as the blaster component. Comparing both isolates the cost of the
+// component runtime. NATS is the only transport the extension supports, so it's the only one served.
+package main
+
+import (
+	"flag"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/nats-io/nats.go"
+	wrpc "wrpc.io/go"
+	wrpcnats "wrpc.io/go/nats"
+
+	server "xk6-wrpc/cmd/blaster-server/bindings"
+)
+
+//go:generate wit-bindgen-wrpc go --out-dir bindings --package xk6-wrpc/cmd/blaster-server/bindings wit
+
+func main() {
+	natsURL := flag.String("nats", nats.DefaultURL, "NATS server url")
+	prefix := flag.String("prefix", "native", "wRPC prefix to serve on")
+	group := flag.String("group", "", "NATS queue group, so replicas share the load")
+	downstream := flag.String("downstream-prefix", "", "wRPC prefix of the blasters hops are forwarded to")
+	dir := flag.String("dir", os.TempDir(), "directory used by fs_burn_bytes")
+	flag.Parse()
+
+	nc, err := nats.Connect(*natsURL)
+	if err != nil {
+		log.Fatalf("failed to connect to NATS: %s", err)
+	}
+	defer nc.Close()
+
+	opts := []wrpcnats.ClientOpt{wrpcnats.WithPrefix(*prefix)}
+	if *group != "" {
+		opts = append(opts, wrpcnats.WithGroup(*group))
+	}
+	client := wrpcnats.NewClient(nc, opts...)
+
+	// hops go to other blasters, invoking our own prefix would only loop back to this server
+	var invoker wrpc.Invoker
+	if *downstream != "" {
+		invoker = wrpcnats.NewClient(nc, wrpcnats.WithPrefix(*downstream))
+	}
+
+	stop, err := server.Serve(client, newBlaster(invoker, *dir), &blasterStream{})
+	if err != nil {
+		log.Fatalf("failed to serve: %s", err)
+	}
+	slog.Info("serving", "nats", *natsURL, "prefix", *prefix, "downstream_prefix", *downstream)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+
+	if err := stop(); err != nil {
+		log.Fatalf("failed to stop serving: %s", err)
+	}
+}
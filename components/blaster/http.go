@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	outgoinghandler "blaster-component/internal/wasi/http/outgoing-handler"
+	"blaster-component/internal/wasi/http/types"
+	"blaster-component/internal/xk6/wrpc/blaster"
+
+	"go.bytecodealliance.org/cm"
+)
+
+// maxWriteSize is the most `blocking-write-and-flush` accepts in a single call.
+const maxWriteSize = 4096
+
+// callHTTP sends a request to rawURL through the host's outgoing handler,
+// POSTing bodySize bytes if set, and reads the whole response.
+func callHTTP(rawURL string, bodySize uint64) (blaster.HTTPCall, error) {
+	var call blaster.HTTPCall
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return call, err
+	}
+	scheme := types.SchemeHTTP()
+	switch u.Scheme {
+	case "http":
+	case "https":
+		scheme = types.SchemeHTTPS()
+	default:
+		return call, errors.New("unsupported scheme " + u.Scheme)
+	}
+
+	start := time.Now()
+
+	req := types.NewOutgoingRequest(types.NewFields())
+	req.SetScheme(cm.Some(scheme))
+	req.SetAuthority(cm.Some(u.Host))
+	req.SetPathWithQuery(cm.Some(u.RequestURI()))
+	if bodySize > 0 {
+		req.SetMethod(types.MethodPost())
+	}
+	outgoing := req.Body()
+	body := *outgoing.OK()
+
+	// the body is written once the request is in flight, the host only buffers so much of it
+	handled := outgoinghandler.Handle(req, cm.None[types.RequestOptions]())
+	if err := handled.Err(); err != nil {
+		// the request is consumed either way, but the body is still ours
+		body.ResourceDrop()
+		return call, errors.New("request: " + err.String())
+	}
+	future := *handled.OK()
+	defer future.ResourceDrop()
+
+	if err := writeBody(body, bodySize); err != nil {
+		return call, err
+	}
+
+	pollable := future.Subscribe()
+	pollable.Block()
+	pollable.ResourceDrop()
+
+	// the future is ready, so the outer option and result are always set
+	ready := future.Get()
+	got := ready.Some().OK()
+	if err := got.Err(); err != nil {
+		return call, errors.New("response: " + err.String())
+	}
+	resp := *got.OK()
+	defer resp.ResourceDrop()
+
+	call.Status = uint16(resp.Status())
+	call.ResponseSize, err = readBody(resp)
+	if err != nil {
+		return call, err
+	}
+	call.DurationNs = uint64(time.Since(start).Nanoseconds())
+
+	return call, nil
+}
+
+func writeBody(body types.OutgoingBody, size uint64) error {
+	writable := body.Write()
+	stream := *writable.OK()
+	chunk := make([]byte, min(size, maxWriteSize))
+	for i := range chunk {
+		chunk[i] = 'x'
+	}
+	for size > 0 {
+		n := min(size, maxWriteSize)
+		written := stream.BlockingWriteAndFlush(cm.ToList(chunk[:n]))
+		if err := written.Err(); err != nil {
+			stream.ResourceDrop()
+			return errors.New("request body: " + err.String())
+		}
+		size -= n
+	}
+	// the stream must be dropped before finishing the body
+	stream.ResourceDrop()
+
+	finished := types.OutgoingBodyFinish(body, cm.None[types.Trailers]())
+	if err := finished.Err(); err != nil {
+		return errors.New("request body: " + err.String())
+	}
+	return nil
+}
+
+func readBody(resp types.IncomingResponse) (uint64, error) {
+	consumed := resp.Consume()
+	body := *consumed.OK()
+	defer body.ResourceDrop()
+	readable := body.Stream()
+	stream := *readable.OK()
+	defer stream.ResourceDrop()
+
+	var size uint64
+	for {
+		read := stream.BlockingRead(64 * 1024)
+		if err := read.Err(); err != nil {
+			if err.Closed() {
+				return size, nil
+			}
+			return size, errors.New("response body: " + err.String())
+		}
+		size += uint64(read.OK().Len())
+	}
+}
@@ -2,10 +2,14 @@
 
 package blaster
 
+import (
+	"go.bytecodealliance.org/cm"
+)
+
 // Exports represents the caller-defined exports from "xk6:wrpc/blaster@0.0.1".
 var Exports struct {
 	// Blast represents the caller-defined, exported function "blast".
 	//
-	//	blast: func(packet: packet)
-	Blast func(packet Packet)
+	//	blast: func(packet: packet) -> result<response, error>
+	Blast func(packet Packet) (result cm.Result[ResponseShape_, Response, Error])
 }
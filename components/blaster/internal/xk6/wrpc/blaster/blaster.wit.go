@@ -12,9 +12,22 @@ import (
 //	record packet {
 //		id: string,
 //		payload: list<u8>,
+//		sent-at-ns: u64,
+//		echo: bool,
 //		mem-burn-mb: u64,
 //		cpu-burn-ms: u64,
 //		wait-ms: u64,
+//		fail-probability: f64,
+//		trap: bool,
+//		exceed-memory: bool,
+//		hops: u32,
+//		fanout: u32,
+//		fs-burn-bytes: u64,
+//		random-burn-bytes: u64,
+//		clock-polls: u64,
+//		http-calls: u32,
+//		http-url: string,
+//		http-body-size: u64,
 //	}
 type Packet struct {
 	_ cm.HostLayout
@@ -24,6 +37,12 @@ type Packet struct {
 	// The payload of the packet
 	Payload cm.List[uint8]
 
+	// Wall clock time the packet was sent, in nanoseconds since the unix epoch
+	SentAtNs uint64
+
+	// Tells the component to send the payload back
+	Echo bool
+
 	// Tells the component to allocate memory
 	MemBurnMb uint64
 
@@ -32,4 +51,162 @@ type Packet struct {
 
 	// Tells the component to sleep
 	WaitMs uint64
+
+	// Probability, between 0 and 1, of the component returning an error
+	FailProbability float64
+
+	// Tells the component to trap
+	Trap bool
+
+	// Tells the component to allocate memory until it exceeds its limit
+	ExceedMemory bool
+
+	// Number of downstream blasters to chain the packet through
+	Hops uint32
+
+	// Number of downstream blasters invoked at each hop
+	Fanout uint32
+
+	// Bytes to write, fsync and read back from a file in the first preopened directory
+	FsBurnBytes uint64
+
+	// Bytes to read from wasi:random
+	RandomBurnBytes uint64
+
+	// Number of times to read the monotonic clock
+	ClockPolls uint64
+
+	// Number of outgoing wasi:http requests to make before answering
+	HTTPCalls uint32
+
+	// URL of the outgoing requests
+	HTTPURL string
+
+	// Size of the outgoing request bodies, requests without a body are GETs
+	HTTPBodySize uint64
+}
+
+// HTTPCall represents the record "xk6:wrpc/blaster@0.0.1#http-call".
+//
+//	record http-call {
+//		status: u16,
+//		response-size: u64,
+//		duration-ns: u64,
+//	}
+type HTTPCall struct {
+	_ cm.HostLayout
+	// Status code of the response
+	Status uint16
+
+	// Bytes read from the response body
+	ResponseSize uint64
+
+	// Time from sending the request to reading the whole response, in nanoseconds
+	DurationNs uint64
+}
+
+// Hop represents the record "xk6:wrpc/blaster@0.0.1#hop".
+//
+//	record hop {
+//		depth: u32,
+//		received-at-ns: u64,
+//		finished-at-ns: u64,
+//	}
+type Hop struct {
+	_ cm.HostLayout
+	// Depth of the hop in the call chain, the first downstream blaster is at depth 1
+	Depth uint32
+
+	// Wall clock time the packet was received, in nanoseconds since the unix epoch
+	ReceivedAtNs uint64
+
+	// Wall clock time the component finished working on the packet, in nanoseconds since
+	// the unix epoch
+	FinishedAtNs uint64
+}
+
+// Response represents the record "xk6:wrpc/blaster@0.0.1#response".
+//
+//	record response {
+//		id: string,
+//		instance-id: string,
+//		invocation: u64,
+//		received-at-ns: u64,
+//		finished-at-ns: u64,
+//		cpu-time-ns: u64,
+//		io-time-ns: u64,
+//		mem-allocated-bytes: u64,
+//		payload-len: u64,
+//		payload-checksum: u32,
+//		payload: list<u8>,
+//		downstream: list<hop>,
+//		http-calls: list<http-call>,
+//	}
+type Response struct {
+	_ cm.HostLayout
+	// The ID of the packet
+	ID string
+
+	// Random identifier of the component instance that handled the packet
+	InstanceID string
+
+	// Number of packets handled by this instance, including this one
+	Invocation uint64
+
+	// Wall clock time the packet was received, in nanoseconds since the unix epoch
+	ReceivedAtNs uint64
+
+	// Wall clock time the component finished working on the packet, in nanoseconds since
+	// the unix epoch
+	FinishedAtNs uint64
+
+	// Time actually spent spinning the CPU, in nanoseconds
+	CPUTimeNs uint64
+
+	// Time spent on filesystem, random and clock work, in nanoseconds
+	IOTimeNs uint64
+
+	// Bytes of memory allocated
+	MemAllocatedBytes uint64
+
+	// Length of the received payload
+	PayloadLen uint64
+
+	// CRC-32 (IEEE) checksum of the received payload
+	PayloadChecksum uint32
+
+	// The received payload, when echo was set
+	Payload cm.List[uint8]
+
+	// Every downstream hop the packet went through
+	Downstream cm.List[Hop]
+
+	// Every outgoing HTTP request made by the component
+	HTTPCalls cm.List[HTTPCall]
+}
+
+// Error represents the record "xk6:wrpc/blaster@0.0.1#error".
+//
+//	record error {
+//		id: string,
+//		message: string,
+//	}
+type Error struct {
+	_ cm.HostLayout
+	// The ID of the packet
+	ID string
+
+	// Why the component failed
+	Message string
+}
+
+// Blast represents the imported function "blast".
+//
+//	blast: func(packet: packet) -> result<response, error>
+//
+//go:nosplit
+func Blast(packet Packet) (result cm.Result[ResponseShape, Response, Error]) {
+	packet_ := &packet
+	wasmimport_Blast((*Packet)(packet_), &result)
+	return
 }
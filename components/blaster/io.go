@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+
+	monotonicclock "blaster-component/internal/wasi/clocks/monotonic-clock"
+	"blaster-component/internal/wasi/filesystem/preopens"
+	"blaster-component/internal/wasi/filesystem/types"
+	"blaster-component/internal/wasi/random/random"
+
+	"go.bytecodealliance.org/cm"
+)
+
+// ioChunkSize bounds the buffers used for filesystem and random work.
+const ioChunkSize = 64 * 1024
+
+var (
+	preopened    types.Descriptor
+	hasPreopened bool
+)
+
+// preopenedDir returns the first directory preopened by the host.
+func preopenedDir() (types.Descriptor, error) {
+	if !hasPreopened {
+		dirs := preopens.GetDirectories().Slice()
+		if len(dirs) == 0 {
+			return 0, errors.New("no preopened directory")
+		}
+		preopened, hasPreopened = dirs[0].F0, true
+	}
+	return preopened, nil
+}
+
+// burnFS writes size bytes to a scratch file, syncs it and reads it back.
+func burnFS(id string, size uint64) error {
+	dir, err := preopenedDir()
+	if err != nil {
+		return err
+	}
+
+	name := "blaster-" + id
+	opened := dir.OpenAt(0, name, types.OpenFlagsCreate|types.OpenFlagsTruncate, types.DescriptorFlagsRead|types.DescriptorFlagsWrite)
+	if err := opened.Err(); err != nil {
+		return errors.New("open: " + err.String())
+	}
+	file := *opened.OK()
+	// the file is closed before being removed
+	defer dir.UnlinkFileAt(name)
+	defer file.ResourceDrop()
+
+	chunk := make([]byte, min(size, ioChunkSize))
+	for i := range chunk {
+		chunk[i] = 'x'
+	}
+	for offset := uint64(0); offset < size; {
+		written := file.Write(cm.ToList(chunk[:min(size-offset, ioChunkSize)]), types.FileSize(offset))
+		if err := written.Err(); err != nil {
+			return errors.New("write: " + err.String())
+		}
+		// a write that makes no progress would otherwise spin forever
+		if *written.OK() == 0 {
+			return errors.New("write: no bytes written")
+		}
+		offset += uint64(*written.OK())
+	}
+
+	synced := file.Sync()
+	if err := synced.Err(); err != nil {
+		return errors.New("sync: " + err.String())
+	}
+
+	for offset := uint64(0); offset < size; {
+		read := file.Read(types.FileSize(min(size-offset, ioChunkSize)), types.FileSize(offset))
+		if err := read.Err(); err != nil {
+			return errors.New("read: " + err.String())
+		}
+		data, eof := read.OK().F0, read.OK().F1
+		if eof {
+			break
+		}
+		if data.Len() == 0 {
+			return errors.New("read: no bytes read")
+		}
+		offset += uint64(data.Len())
+	}
+
+	return nil
+}
+
+// burnRandom reads size bytes from the host's secure random source.
+func burnRandom(size uint64) {
+	for size > 0 {
+		n := min(size, ioChunkSize)
+		random.GetRandomBytes(n)
+		size -= n
+	}
+}
+
+// burnClock reads the monotonic clock polls times.
+func burnClock(polls uint64) {
+	for i := uint64(0); i < polls; i++ {
+		monotonicclock.Now()
+	}
+}
@@ -1,8 +1,14 @@
 package main
 
 import (
+	"blaster-component/internal/wasi/random/random"
 	"blaster-component/internal/xk6/wrpc/blaster"
+	"encoding/hex"
+	"hash/crc32"
+	"runtime"
 	"time"
+
+	"go.bytecodealliance.org/cm"
 )
 
 //go:generate go run go.bytecodealliance.org/cmd/wit-bindgen-go generate --world server --out internal wit
@@ -11,12 +17,116 @@ func init() {
 	blaster.Exports.Blast = blast
 }
 
-func blast(pkt blaster.Packet) {
+type blastResult = cm.Result[blaster.ResponseShape_, blaster.Response, blaster.Error]
+
+// instanceID identifies this instance of the component so clients can tell replicas apart,
+// invocations counts the packets it handled. Instances are single threaded.
+var (
+	instanceID  string
+	invocations uint64
+)
+
+func nextInvocation() (string, uint64) {
+	if instanceID == "" {
+		instanceID = hex.EncodeToString(random.GetRandomBytes(8).Slice())
+	}
+	invocations++
+	return instanceID, invocations
+}
+
+// randomFloat64 returns a number in [0, 1) from the host, math/rand can be seeded the same way in every instance.
+func randomFloat64() float64 {
+	return float64(random.GetRandomU64()>>11) / (1 << 53)
+}
+
+func blast(pkt blaster.Packet) blastResult {
+	// Fault injection
+	if pkt.Trap {
+		panic("blaster: trap requested by packet " + pkt.ID)
+	}
+	if pkt.ExceedMemory {
+		exceedMemory()
+	}
+	if pkt.FailProbability > 0 && randomFloat64() < pkt.FailProbability {
+		return cm.Err[blastResult](blaster.Error{
+			ID:      pkt.ID,
+			Message: "injected failure",
+		})
+	}
+
+	res, err := work(pkt)
+	if err != nil {
+		return cm.Err[blastResult](*err)
+	}
+
+	// Chain the packet through the downstream blasters
+	if pkt.Hops > 0 {
+		downstream, err := forward(pkt)
+		if err != nil {
+			return cm.Err[blastResult](*err)
+		}
+		res.Downstream = cm.ToList(downstream)
+		res.FinishedAtNs = uint64(time.Now().UnixNano())
+	}
+
+	return cm.OK[blastResult](res)
+}
+
+// forward invokes `fanout` downstream blasters with one less hop, returning
+// every hop the packet went through below this one.
+func forward(pkt blaster.Packet) ([]blaster.Hop, *blaster.Error) {
+	next := pkt
+	next.Hops--
+	next.SentAtNs = uint64(time.Now().UnixNano())
+
+	fanout := pkt.Fanout
+	if fanout == 0 {
+		fanout = 1
+	}
+
+	var hops []blaster.Hop
+	for i := uint32(0); i < fanout; i++ {
+		result := blaster.Blast(next)
+		if err := result.Err(); err != nil {
+			return nil, &blaster.Error{
+				ID:      pkt.ID,
+				Message: "downstream: " + err.Message,
+			}
+		}
+
+		res := result.OK()
+		hops = append(hops, blaster.Hop{
+			Depth:        1,
+			ReceivedAtNs: res.ReceivedAtNs,
+			FinishedAtNs: res.FinishedAtNs,
+		})
+		for _, hop := range res.Downstream.Slice() {
+			hop.Depth++
+			hops = append(hops, hop)
+		}
+	}
+
+	return hops, nil
+}
+
+func work(pkt blaster.Packet) (blaster.Response, *blaster.Error) {
+	payload := pkt.Payload.Slice()
+	res := blaster.Response{
+		ID:              pkt.ID,
+		ReceivedAtNs:    uint64(time.Now().UnixNano()),
+		PayloadLen:      uint64(len(payload)),
+		PayloadChecksum: crc32.ChecksumIEEE(payload),
+	}
+	res.InstanceID, res.Invocation = nextInvocation()
+	if pkt.Echo {
+		res.Payload = pkt.Payload
+	}
+
 	// Allocate & hold memory during each invocation
 	var mem []byte
 	if pkt.MemBurnMb > 0 {
 		mem = make([]byte, pkt.MemBurnMb*1024*1024)
-		_ = mem
+		res.MemAllocatedBytes = uint64(len(mem))
 	}
 
 	// Simple sleep
@@ -28,10 +138,62 @@ func blast(pkt blaster.Packet) {
 	if pkt.CPUBurnMs > 0 {
 		// NOTE(lxf): this is a busy loop, it will burn CPU
 		// Getting creative here cause TinyGo 0.34 seem to have issues with timers in wasm.
-		start := time.Now().UnixMilli()
-		for now := time.Now().UnixMilli(); now < start+int64(pkt.CPUBurnMs); now = time.Now().UnixMilli() {
+		start := time.Now()
+		for now := start.UnixMilli(); now < start.UnixMilli()+int64(pkt.CPUBurnMs); now = time.Now().UnixMilli() {
 			_ = time.Now()
 		}
+		res.CPUTimeNs = uint64(time.Since(start).Nanoseconds())
+	}
+
+	// Exercise the host's WASI implementations
+	if pkt.FsBurnBytes > 0 || pkt.RandomBurnBytes > 0 || pkt.ClockPolls > 0 {
+		start := time.Now()
+		if pkt.FsBurnBytes > 0 {
+			if err := burnFS(pkt.ID, pkt.FsBurnBytes); err != nil {
+				return res, &blaster.Error{
+					ID:      pkt.ID,
+					Message: "fs: " + err.Error(),
+				}
+			}
+		}
+		burnRandom(pkt.RandomBurnBytes)
+		burnClock(pkt.ClockPolls)
+		res.IOTimeNs = uint64(time.Since(start).Nanoseconds())
+	}
+
+	// Call the outgoing HTTP dependency
+	if pkt.HTTPCalls > 0 {
+		calls := make([]blaster.HTTPCall, 0, pkt.HTTPCalls)
+		for i := uint32(0); i < pkt.HTTPCalls; i++ {
+			call, err := callHTTP(pkt.HTTPURL, pkt.HTTPBodySize)
+			if err != nil {
+				return res, &blaster.Error{
+					ID:      pkt.ID,
+					Message: "http: " + err.Error(),
+				}
+			}
+			calls = append(calls, call)
+		}
+		res.HTTPCalls = cm.ToList(calls)
+	}
+
+	// keep the allocation alive until we are done
+	runtime.KeepAlive(mem)
+	res.FinishedAtNs = uint64(time.Now().UnixNano())
+
+	return res, nil
+}
+
+// exceedMemory allocates until the runtime runs out of memory and traps.
+func exceedMemory() {
+	var hog [][]byte
+	for {
+		chunk := make([]byte, 64*1024*1024)
+		// touch the pages so they are actually committed
+		for i := 0; i < len(chunk); i += 4096 {
+			chunk[i] = 1
+		}
+		hog = append(hog, chunk)
 	}
 }
 
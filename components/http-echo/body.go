@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+
+	"http-echo-component/internal/wasi/http/types"
+
+	"go.bytecodealliance.org/cm"
+)
+
+// maxWriteSize is the most `blocking-write-and-flush` accepts in a single call.
+const maxWriteSize = 4096
+
+// readBody reads the whole request body.
+func readBody(req types.IncomingRequest) ([]byte, error) {
+	consumed := req.Consume()
+	body := *consumed.OK()
+	readable := body.Stream()
+	stream := *readable.OK()
+
+	var data []byte
+	for {
+		read := stream.BlockingRead(64 * 1024)
+		if err := read.Err(); err != nil {
+			stream.ResourceDrop()
+			types.IncomingBodyFinish(body).ResourceDrop()
+			if err.Closed() {
+				return data, nil
+			}
+			return nil, errors.New("request body: " + err.String())
+		}
+		data = append(data, read.OK().Slice()...)
+	}
+}
+
+// writeResponse sends res, streaming its body and finishing it with its trailers.
+func writeResponse(out types.ResponseOutparam, res *response) {
+	resp := types.NewOutgoingResponse(fields(res.headers))
+	resp.SetStatusCode(res.status)
+	outgoing := resp.Body()
+	body := *outgoing.OK()
+	types.ResponseOutparamSet(out, cm.OK[cm.Result[types.ErrorCodeShape, types.OutgoingResponse, types.ErrorCode]](resp))
+
+	writable := body.Write()
+	stream := *writable.OK()
+	err := writeAll(stream, res.body)
+	if err == nil && res.stream > 0 {
+		err = writeFill(stream, res.stream, res.chunkSize)
+	}
+	// the stream must be dropped before finishing the body
+	stream.ResourceDrop()
+	if err != nil {
+		// the client sees the body ending early
+		body.ResourceDrop()
+		return
+	}
+
+	trailers := cm.None[types.Trailers]()
+	if len(res.trailers) > 0 {
+		trailers = cm.Some(fields(res.trailers))
+	}
+	types.OutgoingBodyFinish(body, trailers)
+}
+
+func writeAll(stream types.OutputStream, data []byte) error {
+	for len(data) > 0 {
+		n := min(len(data), maxWriteSize)
+		written := stream.BlockingWriteAndFlush(cm.ToList(data[:n]))
+		if err := written.Err(); err != nil {
+			return errors.New("response body: " + err.String())
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// writeFill writes size 'x' bytes in chunkSize chunks, each flushed on its own.
+// Chunks are capped at maxWriteSize.
+func writeFill(stream types.OutputStream, size uint64, chunkSize uint64) error {
+	chunkSize = min(chunkSize, maxWriteSize)
+	chunk := make([]byte, min(size, chunkSize))
+	for i := range chunk {
+		chunk[i] = 'x'
+	}
+	for size > 0 {
+		n := min(size, chunkSize)
+		written := stream.BlockingWriteAndFlush(cm.ToList(chunk[:n]))
+		if err := written.Err(); err != nil {
+			return errors.New("response body: " + err.String())
+		}
+		size -= n
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+// Code generated by wit-bindgen-go. DO NOT EDIT.
+
+// Package terminalstdin represents the imported interface "wasi:cli/terminal-stdin@0.2.0".
+//
+// An interface providing an optional `terminal-input` for stdin as a
+// link-time authority.
+package terminalstdin
+
+import (
+	"go.bytecodealliance.org/cm"
+	terminalinput "http-echo-component/internal/wasi/cli/terminal-input"
+)
+
+// TerminalInput represents the imported type alias "wasi:cli/terminal-stdin@0.2.0#terminal-input".
+//
+// See [terminalinput.TerminalInput] for more information.
+type TerminalInput = terminalinput.TerminalInput
+
+// GetTerminalStdin represents the imported function "get-terminal-stdin".
+//
+// If stdin is connected to a terminal, return a `terminal-input` handle
+// allowing further interaction with it.
+//
+//	get-terminal-stdin: func() -> option<terminal-input>
+//
+//go:nosplit
+func GetTerminalStdin() (result cm.Option[TerminalInput]) {
+	wasmimport_GetTerminalStdin(&result)
+	return
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	monotonicclock "http-echo-component/internal/wasi/clocks/monotonic-clock"
+	incominghandler "http-echo-component/internal/wasi/http/incoming-handler"
+	"http-echo-component/internal/wasi/http/types"
+
+	"go.bytecodealliance.org/cm"
+)
+
+//go:generate go run go.bytecodealliance.org/cmd/wit-bindgen-go generate --world server --out internal wit
+
+func init() {
+	incominghandler.Exports.Handle = handle
+}
+
+// defaultChunkSize is used by /stream-bytes when chunk_size isn't set.
+const defaultChunkSize = maxWriteSize
+
+// response is what a route answers, it is written out by handle.
+type response struct {
+	status   types.StatusCode
+	headers  [][2]string
+	trailers [][2]string
+	body     []byte
+	// streamed bytes written after body, in chunks of chunkSize
+	stream    uint64
+	chunkSize uint64
+}
+
+// request is the incoming request, with its body already read.
+type request struct {
+	method  string
+	path    string
+	query   url.Values
+	headers [][2]string
+	body    []byte
+}
+
+// route handles the requests under its prefix, arg is the rest of the path.
+type route func(req *request, arg string) *response
+
+// routes mirror the httpbin endpoints of the same name.
+var routes = map[string]route{
+	"anything":         anything,
+	"get":              method("GET"),
+	"post":             method("POST"),
+	"put":              method("PUT"),
+	"patch":            method("PATCH"),
+	"delete":           method("DELETE"),
+	"echo":             echo,
+	"status":           status,
+	"delay":            delay,
+	"stream-bytes":     streamBytes,
+	"response-headers": responseHeaders,
+	"trailers":         trailers,
+	"redirect-to":      redirectTo,
+	"redirect":         redirect,
+}
+
+func handle(in types.IncomingRequest, out types.ResponseOutparam) {
+	req, err := readRequest(in)
+	if err != nil {
+		writeResponse(out, textResponse(400, err.Error()))
+		return
+	}
+
+	name, arg, _ := strings.Cut(strings.TrimPrefix(req.path, "/"), "/")
+	r, ok := routes[name]
+	if !ok {
+		writeResponse(out, textResponse(404, "no route for "+req.path))
+		return
+	}
+	writeResponse(out, r(req, arg))
+}
+
+func textResponse(status types.StatusCode, msg string) *response {
+	return &response{
+		status:  status,
+		headers: [][2]string{{"content-type", "text/plain"}},
+		body:    []byte(msg + "\n"),
+	}
+}
+
+func jsonResponse(v any) *response {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return textResponse(500, err.Error())
+	}
+	return &response{
+		status:  200,
+		headers: [][2]string{{"content-type", "application/json"}},
+		body:    body,
+	}
+}
+
+// anythingBody describes the request, like httpbin's /anything.
+type anythingBody struct {
+	Method  string              `json:"method"`
+	Path    string              `json:"path"`
+	Args    map[string][]string `json:"args"`
+	Headers map[string][]string `json:"headers"`
+	Data    string              `json:"data"`
+}
+
+// anything answers a JSON description of the request.
+func anything(req *request, _ string) *response {
+	headers := make(map[string][]string)
+	for _, h := range req.headers {
+		headers[h[0]] = append(headers[h[0]], h[1])
+	}
+	return jsonResponse(anythingBody{
+		Method:  req.method,
+		Path:    req.path,
+		Args:    req.query,
+		Headers: headers,
+		Data:    string(req.body),
+	})
+}
+
+// method is like anything, but only accepts requests with the given method.
+func method(m string) route {
+	return func(req *request, arg string) *response {
+		if req.method != m {
+			res := textResponse(405, "expected "+m+", got "+req.method)
+			res.headers = append(res.headers, [2]string{"allow", m})
+			return res
+		}
+		return anything(req, arg)
+	}
+}
+
+// echo answers the request body as is, with its content type.
+func echo(req *request, _ string) *response {
+	res := &response{status: 200, body: req.body}
+	for _, h := range req.headers {
+		if strings.EqualFold(h[0], "content-type") {
+			res.headers = append(res.headers, h)
+		}
+	}
+	return res
+}
+
+// status answers /status/{code} with an empty body.
+func status(_ *request, arg string) *response {
+	code, err := strconv.ParseUint(arg, 10, 16)
+	if err != nil || code < 100 || code > 599 {
+		return textResponse(400, "invalid status code "+strconv.Quote(arg))
+	}
+	return &response{status: types.StatusCode(code)}
+}
+
+// delay answers /delay/{ms} like /anything, after waiting ms milliseconds.
+// Unlike httpbin the delay is in milliseconds and isn't capped.
+func delay(req *request, arg string) *response {
+	ms, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return textResponse(400, "invalid delay "+strconv.Quote(arg))
+	}
+	sleep := monotonicclock.SubscribeDuration(monotonicclock.Duration(ms * 1_000_000))
+	sleep.Block()
+	sleep.ResourceDrop()
+	return anything(req, "")
+}
+
+// streamBytes answers /stream-bytes/{n} with n bytes, written in chunk_size chunks.
+func streamBytes(req *request, arg string) *response {
+	n, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return textResponse(400, "invalid size "+strconv.Quote(arg))
+	}
+	chunkSize := uint64(defaultChunkSize)
+	if s := req.query.Get("chunk_size"); s != "" {
+		chunkSize, err = strconv.ParseUint(s, 10, 64)
+		if err != nil || chunkSize == 0 {
+			return textResponse(400, "invalid chunk_size "+strconv.Quote(s))
+		}
+	}
+	return &response{
+		status:    200,
+		headers:   [][2]string{{"content-type", "application/octet-stream"}},
+		stream:    n,
+		chunkSize: chunkSize,
+	}
+}
+
+// responseHeaders sets every query parameter as a response header, and answers them as JSON.
+func responseHeaders(req *request, _ string) *response {
+	res := jsonResponse(req.query)
+	res.headers = append(res.headers, queryFields(req.query)...)
+	return res
+}
+
+// trailers is like /response-headers, but sends the query parameters as trailers.
+func trailers(req *request, _ string) *response {
+	res := jsonResponse(req.query)
+	res.trailers = queryFields(req.query)
+	return res
+}
+
+// redirectTo redirects to the url query parameter, with status_code or 302.
+func redirectTo(req *request, _ string) *response {
+	location := req.query.Get("url")
+	if location == "" {
+		return textResponse(400, "missing url")
+	}
+	code := types.StatusCode(302)
+	if s := req.query.Get("status_code"); s != "" {
+		n, err := strconv.ParseUint(s, 10, 16)
+		if err != nil || n < 300 || n > 399 {
+			return textResponse(400, "invalid status_code "+strconv.Quote(s))
+		}
+		code = types.StatusCode(n)
+	}
+	return &response{
+		status:  code,
+		headers: [][2]string{{"location", location}},
+	}
+}
+
+// redirect answers /redirect/{n} by redirecting n times before landing on /anything.
+func redirect(_ *request, arg string) *response {
+	n, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil || n == 0 {
+		return textResponse(400, "invalid redirect count "+strconv.Quote(arg))
+	}
+	location := "/anything"
+	if n > 1 {
+		location = "/redirect/" + strconv.FormatUint(n-1, 10)
+	}
+	return &response{
+		status:  302,
+		headers: [][2]string{{"location", location}},
+	}
+}
+
+func queryFields(query url.Values) [][2]string {
+	var fields [][2]string
+	for k, vs := range query {
+		for _, v := range vs {
+			fields = append(fields, [2]string{strings.ToLower(k), v})
+		}
+	}
+	return fields
+}
+
+func readRequest(in types.IncomingRequest) (*request, error) {
+	defer in.ResourceDrop()
+
+	method := in.Method()
+	req := &request{method: strings.ToUpper(method.String())}
+	if other := method.Other(); other != nil {
+		req.method = *other
+	}
+
+	pathWithQuery := "/"
+	if p := in.PathWithQuery(); !p.None() {
+		pathWithQuery = p.Value()
+	}
+	u, err := url.ParseRequestURI(pathWithQuery)
+	if err != nil {
+		return nil, err
+	}
+	req.path = u.Path
+	req.query = u.Query()
+
+	headers := in.Headers()
+	for _, entry := range headers.Entries().Slice() {
+		req.headers = append(req.headers, [2]string{string(entry.F0), string(cm.List[uint8](entry.F1).Slice())})
+	}
+	headers.ResourceDrop()
+
+	req.body, err = readBody(in)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// fields builds wasi:http fields, invalid names or values are dropped.
+func fields(entries [][2]string) types.Fields {
+	f := types.NewFields()
+	for _, e := range entries {
+		f.Append(types.FieldKey(e[0]), types.FieldValue(cm.ToList([]byte(e[1]))))
+	}
+	return f
+}
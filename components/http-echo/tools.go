@@ -0,0 +1,7 @@
+//go:build tools
+
+package main
+
+import (
+	_ "go.bytecodealliance.org/cmd/wit-bindgen-go"
+)
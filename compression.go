@@ -0,0 +1,138 @@
+package k6wrpc
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"go.k6.io/k6/lib/netext/httpext"
+)
+
+// compressBody compresses the body with a comma separated list of algorithms,
+// applied in order, returning the matching Content-Encoding header value.
+func compressBody(compression string, body []byte) ([]byte, string, error) {
+	var contentEncoding []string
+	for _, algo := range strings.Split(compression, ",") {
+		algo = strings.TrimSpace(algo)
+		compressionType, err := httpext.CompressionTypeString(algo)
+		if err != nil {
+			return nil, "", fmt.Errorf("unknown compression algorithm %q, supported algorithms are %v",
+				algo, httpext.CompressionTypeValues())
+		}
+
+		var buf bytes.Buffer
+		var w io.WriteCloser
+		switch compressionType {
+		case httpext.CompressionTypeGzip:
+			w = gzip.NewWriter(&buf)
+		case httpext.CompressionTypeDeflate:
+			w = zlib.NewWriter(&buf)
+		case httpext.CompressionTypeZstd:
+			var err error
+			if w, err = zstd.NewWriter(&buf); err != nil {
+				return nil, "", err
+			}
+		case httpext.CompressionTypeBr:
+			w = brotli.NewWriter(&buf)
+		}
+		if _, err := w.Write(body); err != nil {
+			_ = w.Close()
+			return nil, "", err
+		}
+		if err := w.Close(); err != nil {
+			return nil, "", err
+		}
+
+		body = buf.Bytes()
+		contentEncoding = append(contentEncoding, compressionType.String())
+	}
+
+	return body, strings.Join(contentEncoding, ", "), nil
+}
+
+// decoderChain reads through a chain of decoders, closing all of them once done.
+// The body under them is left to the caller.
+type decoderChain struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (c *decoderChain) Close() error {
+	var errs []error
+	for i := len(c.closers) - 1; i >= 0; i-- {
+		if err := c.closers[i].Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	c.closers = nil
+	return errors.Join(errs...)
+}
+
+// decompressBody wraps the body with decoders for every Content-Encoding we support,
+// in reverse order of application. Unknown encodings are passed through as is.
+// The returned reader must be closed to release the decoders.
+func decompressBody(contentEncoding string, body io.Reader) (io.ReadCloser, error) {
+	chain := &decoderChain{Reader: body}
+	if contentEncoding == "" {
+		return chain, nil
+	}
+
+	// HEAD and 204 responses keep the Content-Encoding of the body they don't have,
+	// gzip and zlib readers fail on an empty one
+	buffered := bufio.NewReader(body)
+	if _, err := buffered.Peek(1); err == io.EOF {
+		return chain, nil
+	}
+	chain.Reader = buffered
+
+	encodings := strings.Split(contentEncoding, ",")
+	for i := len(encodings) - 1; i >= 0; i-- {
+		compressionType, err := httpext.CompressionTypeString(strings.TrimSpace(encodings[i]))
+		if err != nil {
+			continue
+		}
+
+		var decoder io.ReadCloser
+		switch compressionType {
+		case httpext.CompressionTypeGzip:
+			decoder, err = gzip.NewReader(chain.Reader)
+		case httpext.CompressionTypeDeflate:
+			decoder, err = zlib.NewReader(chain.Reader)
+		case httpext.CompressionTypeZstd:
+			var d *zstd.Decoder
+			d, err = zstd.NewReader(chain.Reader)
+			if err == nil {
+				decoder = d.IOReadCloser()
+			}
+		case httpext.CompressionTypeBr:
+			decoder = io.NopCloser(brotli.NewReader(chain.Reader))
+		}
+		if err != nil {
+			_ = chain.Close()
+			return nil, fmt.Errorf("error decompressing response body (%s): %w", compressionType, err)
+		}
+		chain.Reader = decoder
+		chain.closers = append(chain.closers, decoder)
+	}
+
+	return chain, nil
+}
+
+// countingReader keeps track of the bytes read from the wire.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(b []byte) (int, error) {
+	n, err := c.r.Read(b)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,90 @@
+package k6wrpc
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	body := bytes.Repeat([]byte("hello wrpc "), 128)
+
+	testdata := map[string]string{
+		"gzip":       "gzip",
+		"deflate":    "deflate",
+		"br":         "br",
+		"zstd":       "zstd",
+		"gzip, br":   "gzip, br",
+		"zstd,gzip ": "zstd, gzip",
+	}
+	for compression, contentEncoding := range testdata {
+		compression, contentEncoding := compression, contentEncoding
+		t.Run(compression, func(t *testing.T) {
+			t.Parallel()
+			compressed, encoding, err := compressBody(compression, body)
+			require.NoError(t, err)
+			assert.Equal(t, contentEncoding, encoding)
+			assert.Less(t, len(compressed), len(body))
+
+			wire := &countingReader{r: bytes.NewReader(compressed)}
+			decoded, err := decompressBody(encoding, wire)
+			require.NoError(t, err)
+			data, err := io.ReadAll(decoded)
+			require.NoError(t, err)
+			assert.Equal(t, body, data)
+			assert.Equal(t, int64(len(compressed)), wire.n)
+			require.NoError(t, decoded.Close())
+		})
+	}
+}
+
+func TestCompressionUnknown(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := compressBody("lzw", []byte("hello"))
+	assert.ErrorContains(t, err, `unknown compression algorithm "lzw"`)
+
+	// unknown response encodings are passed through
+	decoded, err := decompressBody("identity", bytes.NewReader([]byte("hello")))
+	require.NoError(t, err)
+	data, err := io.ReadAll(decoded)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), data)
+}
+
+func TestDecompressBodyClose(t *testing.T) {
+	t.Parallel()
+
+	compressed, encoding, err := compressBody("zstd", []byte("hello"))
+	require.NoError(t, err)
+	decoded, err := decompressBody(encoding, bytes.NewReader(compressed))
+	require.NoError(t, err)
+	require.NoError(t, decoded.Close())
+
+	// the zstd decoder is released, so it can't be read from anymore
+	_, err = io.ReadAll(decoded)
+	assert.Error(t, err)
+
+	// decoders created before an invalid one are released too
+	_, err = decompressBody("zstd, gzip", bytes.NewReader([]byte("not gzip")))
+	assert.ErrorContains(t, err, "error decompressing response body (gzip)")
+}
+
+func TestDecompressBodyEmpty(t *testing.T) {
+	t.Parallel()
+
+	// HEAD and 204 responses can carry a Content-Encoding without a body
+	for _, encoding := range []string{"gzip", "deflate", "zstd", "br", "gzip, br"} {
+		decoded, err := decompressBody(encoding, bytes.NewReader(nil))
+		require.NoError(t, err, encoding)
+		body, err := io.ReadAll(decoded)
+		require.NoError(t, err, encoding)
+		assert.Empty(t, body, encoding)
+		require.NoError(t, decoded.Close(), encoding)
+	}
+}
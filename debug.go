@@ -0,0 +1,187 @@
+package k6wrpc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+	wrpc "wrpc.io/go"
+)
+
+const (
+	// dump invocations and http heads
+	debugHeaders = "headers"
+	// also dump encoded params and http bodies
+	debugFull = "full"
+)
+
+// DefaultDebugRate is how many dumps per second are printed across all clients,
+// so debugging stays usable during a load test.
+var DefaultDebugRate = 10.0
+
+// debugMaxBody truncates the bodies and params dumped in full mode.
+const debugMaxBody = 4096
+
+// debugLimiter rate limits the dumps of every client, counting the ones it drops.
+type debugLimiter struct {
+	limiter    *rate.Limiter
+	suppressed atomic.Int64
+}
+
+func newDebugLimiter(perSecond float64) *debugLimiter {
+	return &debugLimiter{limiter: rate.NewLimiter(rate.Limit(perSecond), max(int(perSecond), 1))}
+}
+
+// debugger prints the invocations of a client, it is nil when debugging is off.
+type debugger struct {
+	mode    string
+	logger  logrus.FieldLogger
+	limiter *debugLimiter
+}
+
+// debugger returns the debugger of a client, mode defaults to the K6_WRPC_DEBUG environment variable.
+func (mi *ModuleInstance) debugger(mode string) (*debugger, error) {
+	env := mi.vu.InitEnv()
+	if mode == "" && env != nil && env.LookupEnv != nil {
+		mode, _ = env.LookupEnv("K6_WRPC_DEBUG")
+	}
+	switch mode {
+	case "":
+		return nil, nil
+	case debugHeaders, debugFull:
+	default:
+		return nil, newParamsError("options.debug", "expected %q or %q, got %q", debugHeaders, debugFull, mode)
+	}
+
+	r := mi.rootModule
+	r.debugLimiterOnce.Do(func() {
+		r.debugLimiter = newDebugLimiter(DefaultDebugRate)
+	})
+
+	var logger logrus.FieldLogger = logrus.StandardLogger()
+	if env != nil && env.Logger != nil {
+		logger = env.Logger
+	}
+	return &debugger{
+		mode:    mode,
+		logger:  logger.WithField("source", "wrpc-debug"),
+		limiter: r.debugLimiter,
+	}, nil
+}
+
+func (d *debugger) full() bool {
+	return d != nil && d.mode == debugFull
+}
+
+// dump prints msg unless over the rate limit, mentioning the dumps dropped since the last one.
+func (d *debugger) dump(msg string) {
+	if d == nil {
+		return
+	}
+	if !d.limiter.limiter.Allow() {
+		d.limiter.suppressed.Add(1)
+		return
+	}
+	if n := d.limiter.suppressed.Swap(0); n > 0 {
+		msg += fmt.Sprintf("\n(%d dumps suppressed by the rate limit)", n)
+	}
+	d.logger.Info(msg)
+}
+
+// dumpInvocation prints an invocation and the encoded size of its params and results.
+func (d *debugger) dumpInvocation(instance, name string, params []byte, results int64, duration time.Duration, err error) {
+	if d == nil {
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Invocation:\n%s#%s params=%dB results=%dB duration=%s", instance, name, len(params), results, duration)
+	if err != nil {
+		fmt.Fprintf(&b, " error=%q", err)
+	}
+	if d.full() && len(params) > 0 {
+		b.WriteString("\n\n")
+		b.WriteString(dumpBytes(params, true))
+	}
+	d.dump(b.String())
+}
+
+// dumpHTTP prints a request and its response like `k6 --http-debug`, resp is nil if the request failed.
+func (d *debugger) dumpHTTP(
+	method string,
+	u *neturl.URL,
+	headers []*wrpc.Tuple2[string, [][]uint8],
+	body []byte,
+	resp *httpResponse,
+	err error,
+) {
+	if d == nil {
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Request:\n%s %s HTTP/1.1\nHost: %s\n", method, u.RequestURI(), u.Host)
+	for _, header := range headers {
+		for _, v := range header.V1 {
+			fmt.Fprintf(&b, "%s: %s\n", header.V0, v)
+		}
+	}
+	if d.full() && len(body) > 0 {
+		b.WriteString("\n")
+		b.WriteString(dumpBytes(body, false))
+	}
+
+	if err != nil {
+		fmt.Fprintf(&b, "\nError: %s", err)
+		d.dump(b.String())
+		return
+	}
+
+	fmt.Fprintf(&b, "\nResponse:\nHTTP/1.1 %d %s\n", resp.Status, http.StatusText(resp.Status))
+	names := make([]string, 0, len(resp.Headers))
+	for name := range resp.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range resp.Headers[name] {
+			fmt.Fprintf(&b, "%s: %s\n", name, v)
+		}
+	}
+	if d.full() && len(resp.Body) > 0 {
+		b.WriteString("\n")
+		b.WriteString(dumpBytes(resp.Body, false))
+	}
+	d.dump(b.String())
+}
+
+// dumpBytes prints text as is and binary data as hex, truncated to debugMaxBody.
+func dumpBytes(data []byte, binary bool) string {
+	truncated := ""
+	if len(data) > debugMaxBody {
+		truncated = fmt.Sprintf("\n(truncated, %d of %d bytes)", debugMaxBody, len(data))
+		data = data[:debugMaxBody]
+	}
+	if binary || !isText(data) {
+		return strings.TrimSuffix(hex.Dump(data), "\n") + truncated
+	}
+	return string(data) + truncated
+}
+
+func isText(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+	for _, c := range data {
+		if c < 0x20 && c != '\n' && c != '\r' && c != '\t' {
+			return false
+		}
+	}
+	return true
+}
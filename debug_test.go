@@ -0,0 +1,105 @@
+package k6wrpc
+
+import (
+	"errors"
+	"net/http"
+	neturl "net/url"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	wrpc "wrpc.io/go"
+)
+
+func newTestDebugger(mode string, perSecond float64) (*debugger, *test.Hook) {
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+	return &debugger{
+		mode:    mode,
+		logger:  logger,
+		limiter: newDebugLimiter(perSecond),
+	}, hook
+}
+
+func TestDebugHTTP(t *testing.T) {
+	t.Parallel()
+
+	u, err := neturl.Parse("http://localhost:8000/anything?page=1")
+	require.NoError(t, err)
+	headers := []*wrpc.Tuple2[string, [][]uint8]{{V0: "Accept", V1: [][]uint8{[]byte("text/plain")}}}
+	resp := &httpResponse{
+		Status:  http.StatusOK,
+		Headers: http.Header{"Content-Type": {"text/plain"}},
+		Body:    []byte("response body"),
+	}
+
+	d, hook := newTestDebugger(debugHeaders, 10)
+	d.dumpHTTP(http.MethodPost, u, headers, []byte("request body"), resp, nil)
+	require.Len(t, hook.Entries, 1)
+	msg := hook.LastEntry().Message
+	assert.Contains(t, msg, "POST /anything?page=1 HTTP/1.1\nHost: localhost:8000\nAccept: text/plain\n")
+	assert.Contains(t, msg, "HTTP/1.1 200 OK\nContent-Type: text/plain\n")
+	assert.NotContains(t, msg, "body")
+
+	d, hook = newTestDebugger(debugFull, 10)
+	d.dumpHTTP(http.MethodPost, u, headers, []byte("request body"), resp, nil)
+	msg = hook.LastEntry().Message
+	assert.Contains(t, msg, "request body")
+	assert.Contains(t, msg, "response body")
+
+	d.dumpHTTP(http.MethodGet, u, nil, nil, nil, errors.New("no responders"))
+	assert.Contains(t, hook.LastEntry().Message, "Error: no responders")
+}
+
+func TestDebugInvocation(t *testing.T) {
+	t.Parallel()
+
+	d, hook := newTestDebugger(debugFull, 10)
+	d.dumpInvocation("xk6:wrpc/blaster@0.0.1", "blast", []byte{0x01, 0x02}, 12, 0, nil)
+	msg := hook.LastEntry().Message
+	assert.Contains(t, msg, "xk6:wrpc/blaster@0.0.1#blast params=2B results=12B")
+	assert.Contains(t, msg, "00000000  01 02")
+
+	var off *debugger
+	off.dumpInvocation("xk6:wrpc/blaster@0.0.1", "blast", nil, 0, 0, nil)
+}
+
+func TestDebugRateLimit(t *testing.T) {
+	t.Parallel()
+
+	d, hook := newTestDebugger(debugHeaders, 2)
+	for i := 0; i < 5; i++ {
+		d.dump("dump")
+	}
+	assert.Len(t, hook.Entries, 2)
+	assert.Equal(t, int64(3), d.limiter.suppressed.Load())
+}
+
+func TestDumpBytes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "hello\n", dumpBytes([]byte("hello\n"), false))
+	assert.Contains(t, dumpBytes([]byte{0x00, 0xff}, false), "00000000  00 ff")
+	assert.Contains(t, dumpBytes(make([]byte, debugMaxBody+1), false), "truncated, 4096 of 4097 bytes")
+}
+
+func TestDebugOption(t *testing.T) {
+	t.Parallel()
+
+	_, mi := getTestModuleInstance(t)
+
+	d, err := mi.debugger("")
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	d, err = mi.debugger(debugFull)
+	require.NoError(t, err)
+	assert.True(t, d.full())
+
+	_, err = mi.debugger("bodies")
+	var pErr *paramsError
+	require.ErrorAs(t, err, &pErr)
+	assert.Equal(t, "options.debug", pErr.Path)
+}
@@ -0,0 +1,110 @@
+package k6wrpc
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand/v2"
+	"sync"
+)
+
+// Supported distributions for randomized packet parameters.
+const (
+	distFixed       = "fixed"
+	distUniform     = "uniform"
+	distNormal      = "normal"
+	distExponential = "exponential"
+	distPareto      = "pareto"
+)
+
+// distribution is a non-negative random variable, sampled per blast.
+// Only the parameters of its kind are used, max caps the samples when set.
+type distribution struct {
+	kind   string
+	value  float64
+	min    float64
+	max    float64
+	mean   float64
+	stddev float64
+	scale  float64
+	shape  float64
+}
+
+func (d *distribution) sample(rng *rand.Rand) float64 {
+	var v float64
+	switch d.kind {
+	case distUniform:
+		v = d.min + rng.Float64()*(d.max-d.min)
+	case distNormal:
+		v = d.mean + rng.NormFloat64()*d.stddev
+	case distExponential:
+		v = rng.ExpFloat64() * d.mean
+	case distPareto:
+		// inverse transform sampling, 1-u is in (0, 1]
+		v = d.scale / math.Pow(1-rng.Float64(), 1/d.shape)
+	default:
+		v = d.value
+	}
+	if d.max > 0 && v > d.max {
+		v = d.max
+	}
+	return math.Max(v, 0)
+}
+
+// bound returns the largest sample of d, or false if it has none.
+func (d *distribution) bound() (float64, bool) {
+	switch {
+	case d.kind == distFixed:
+		return d.value, true
+	case d.max > 0:
+		return d.max, true
+	default:
+		return 0, false
+	}
+}
+
+// packetRand samples the distributions of a blastCall, which can be invoked from several goroutines at once.
+type packetRand struct {
+	mu  sync.Mutex
+	src *rand.ChaCha8
+	rng *rand.Rand
+}
+
+// newPacketRand seeds a packetRand, each VU gets its own stream for the same seed.
+func newPacketRand(seed uint64, vuID uint64) *packetRand {
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:], seed)
+	binary.LittleEndian.PutUint64(key[8:], vuID)
+	src := rand.NewChaCha8(key)
+	return &packetRand{src: src, rng: rand.New(src)}
+}
+
+// Uint returns a rounded sample of d, or 0 if d is nil.
+func (r *packetRand) Uint(d *distribution) uint64 {
+	if d == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// heavy tails can sample values, even +Inf, that don't fit in an uint64
+	v := math.Round(d.sample(r.rng))
+	if v >= math.MaxUint64 {
+		return math.MaxUint64
+	}
+	return uint64(v)
+}
+
+// IntN returns a random index in [0, n).
+func (r *packetRand) IntN(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rng.IntN(n)
+}
+
+// Bytes returns n random bytes.
+func (r *packetRand) Bytes(n uint64) []byte {
+	b := make([]byte, n)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, _ = r.src.Read(b)
+	return b
+}
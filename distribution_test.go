@@ -0,0 +1,82 @@
+package k6wrpc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistributionDecode(t *testing.T) {
+	t.Parallel()
+
+	runtime, _ := getTestModuleInstance(t)
+	rt := runtime.VU.Runtime()
+	v, err := rt.RunString(`({
+		fixed: 10,
+		uniform: { dist: "uniform", min: 5, max: 15 },
+		pareto: { dist: "pareto", scale: 2, shape: 1.5, max: 1000 },
+	})`)
+	require.NoError(t, err)
+
+	var fixed, uniform, pareto *distribution
+	d := newParamsDecoder(rt, "packet", v)
+	d.Distribution("fixed", &fixed)
+	d.Distribution("uniform", &uniform)
+	d.Distribution("pareto", &pareto)
+	require.NoError(t, d.Err())
+
+	assert.Equal(t, &distribution{kind: distFixed, value: 10}, fixed)
+	assert.Equal(t, &distribution{kind: distUniform, min: 5, max: 15}, uniform)
+	assert.Equal(t, &distribution{kind: distPareto, scale: 2, shape: 1.5, max: 1000}, pareto)
+}
+
+func TestDistributionSample(t *testing.T) {
+	t.Parallel()
+
+	const samples = 10000
+	testdata := map[string]struct {
+		dist     distribution
+		min, max float64
+		mean     float64
+	}{
+		"fixed":       {distribution{kind: distFixed, value: 7}, 7, 7, 7},
+		"uniform":     {distribution{kind: distUniform, min: 10, max: 20}, 10, 20, 15},
+		"normal":      {distribution{kind: distNormal, mean: 100, stddev: 10}, 0, math.Inf(1), 100},
+		"exponential": {distribution{kind: distExponential, mean: 50}, 0, math.Inf(1), 50},
+		// mean of scale * shape / (shape - 1)
+		"pareto":  {distribution{kind: distPareto, scale: 10, shape: 3}, 10, math.Inf(1), 15},
+		"capped":  {distribution{kind: distExponential, mean: 50, max: 1}, 0, 1, 1},
+		"clamped": {distribution{kind: distNormal, mean: -100, stddev: 1}, 0, 0, 0},
+	}
+	for name, data := range testdata {
+		data := data
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := newPacketRand(42, 1)
+			var sum float64
+			for range samples {
+				v := data.dist.sample(r.rng)
+				require.GreaterOrEqual(t, v, data.min)
+				require.LessOrEqual(t, v, data.max)
+				sum += v
+			}
+			assert.InDelta(t, data.mean, sum/samples, data.mean*0.05)
+		})
+	}
+}
+
+func TestPacketRandSeed(t *testing.T) {
+	t.Parallel()
+
+	dist := &distribution{kind: distExponential, mean: 100}
+	a, b := newPacketRand(1, 1), newPacketRand(1, 1)
+	for range 100 {
+		require.Equal(t, a.Uint(dist), b.Uint(dist))
+	}
+	assert.NotEqual(t, newPacketRand(1, 1).Bytes(64), newPacketRand(1, 2).Bytes(64))
+	assert.Equal(t, a.Bytes(64), b.Bytes(64))
+	assert.Len(t, a.Bytes(1024), 1024)
+	assert.Zero(t, a.Uint(nil))
+}
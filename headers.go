@@ -0,0 +1,82 @@
+package k6wrpc
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/grafana/sobek"
+	"golang.org/x/net/http/httpguts"
+	wrpc "wrpc.io/go"
+)
+
+// jsHeadersToWrpc converts the `headers` request param into wRPC fields, keeping the
+// order in which they were declared. Values can be strings, numbers, arrays for
+// multi-valued headers, or ArrayBuffer / Uint8Array for raw byte values.
+func jsHeadersToWrpc(rt *sobek.Runtime, v sobek.Value) ([]*wrpc.Tuple2[string, [][]uint8], error) {
+	if v == nil || sobek.IsUndefined(v) || sobek.IsNull(v) {
+		return nil, nil
+	}
+	obj, ok := v.(*sobek.Object)
+	if !ok {
+		return nil, fmt.Errorf("invalid headers: expected an object, got %s", v.ExportType())
+	}
+
+	keys := obj.Keys()
+	headers := make([]*wrpc.Tuple2[string, [][]uint8], 0, len(keys))
+	for _, name := range keys {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return nil, fmt.Errorf("invalid header name %q", name)
+		}
+
+		var values [][]uint8
+		switch data := obj.Get(name).Export().(type) {
+		case []interface{}:
+			for i, el := range data {
+				value, err := headerValueToWrpc(el)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value at index %d for header %q: %w", i, name, err)
+				}
+				values = append(values, value)
+			}
+		default:
+			value, err := headerValueToWrpc(data)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for header %q: %w", name, err)
+			}
+			values = append(values, value)
+		}
+
+		headers = append(headers, &wrpc.Tuple2[string, [][]uint8]{
+			V0: name,
+			V1: values,
+		})
+	}
+
+	return headers, nil
+}
+
+func headerValueToWrpc(v interface{}) ([]uint8, error) {
+	var value string
+	switch data := v.(type) {
+	// raw byte values are sent as is
+	case sobek.ArrayBuffer:
+		return data.Bytes(), nil
+	case []byte:
+		return data, nil
+	case string:
+		value = data
+	case int64:
+		value = strconv.FormatInt(data, 10)
+	case float64:
+		value = strconv.FormatFloat(data, 'f', -1, 64)
+	case bool:
+		value = strconv.FormatBool(data)
+	default:
+		return nil, fmt.Errorf("unsupported type %T, expected a string, number, ArrayBuffer or an array of them", v)
+	}
+
+	if !httpguts.ValidHeaderFieldValue(value) {
+		return nil, fmt.Errorf("%q contains invalid characters", value)
+	}
+	return []byte(value), nil
+}
@@ -0,0 +1,57 @@
+package k6wrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	wrpc "wrpc.io/go"
+)
+
+func TestJSHeadersToWrpc(t *testing.T) {
+	t.Parallel()
+
+	runtime, _ := getTestModuleInstance(t)
+	rt := runtime.VU.Runtime()
+
+	v, err := rt.RunString(`({
+		"X-Zulu": "z",
+		"Accept": ["text/plain", "application/json"],
+		"X-Number": 42,
+		"X-Binary": new Uint8Array([0, 255]).buffer,
+	})`)
+	require.NoError(t, err)
+
+	headers, err := jsHeadersToWrpc(rt, v)
+	require.NoError(t, err)
+	assert.Equal(t, []*wrpc.Tuple2[string, [][]uint8]{
+		{V0: "X-Zulu", V1: [][]uint8{[]byte("z")}},
+		{V0: "Accept", V1: [][]uint8{[]byte("text/plain"), []byte("application/json")}},
+		{V0: "X-Number", V1: [][]uint8{[]byte("42")}},
+		{V0: "X-Binary", V1: [][]uint8{{0, 255}}},
+	}, headers)
+}
+
+func TestJSHeadersToWrpcInvalid(t *testing.T) {
+	t.Parallel()
+
+	testdata := map[string]string{
+		`({"Bad Name": "v"})`:       `invalid header name "Bad Name"`,
+		`({"X-Header": "a\nb"})`:    `invalid value for header "X-Header": "a\nb" contains invalid characters`,
+		`({"X-Header": {a: 1}})`:    `invalid value for header "X-Header": unsupported type map[string]interface {}`,
+		`({"X-Header": ["a", {}]})`: `invalid value at index 1 for header "X-Header"`,
+		`"headers"`:                 `invalid headers: expected an object`,
+	}
+	for expr, msg := range testdata {
+		expr, msg := expr, msg
+		t.Run(expr, func(t *testing.T) {
+			t.Parallel()
+			runtime, _ := getTestModuleInstance(t)
+			rt := runtime.VU.Runtime()
+			v, err := rt.RunString(expr)
+			require.NoError(t, err)
+			_, err = jsHeadersToWrpc(rt, v)
+			assert.ErrorContains(t, err, msg)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	math "math"
 	sync "sync"
 	atomic "sync/atomic"
+	utf8 "unicode/utf8"
 	wrpc "wrpc.io/go"
 )
 
@@ -20,18 +21,44 @@ type Packet struct {
 	Id string
 	// The payload of the packet
 	Payload []uint8
+	// Wall clock time the packet was sent, in nanoseconds since the unix epoch
+	SentAtNs uint64
+	// Tells the component to send the payload back
+	Echo bool
 	// Tells the component to allocate memory
 	MemBurnMb uint64
 	// Tells the component to spinlock the CPU
 	CpuBurnMs uint64
 	// Tells the component to sleep
 	WaitMs uint64
+	// Probability, between 0 and 1, of the component returning an error
+	FailProbability float64
+	// Tells the component to trap
+	Trap bool
+	// Tells the component to allocate memory until it exceeds its limit
+	ExceedMemory bool
+	// Number of downstream blasters to chain the packet through
+	Hops uint32
+	// Number of downstream blasters invoked at each hop
+	Fanout uint32
+	// Bytes to write, fsync and read back from a file in the first preopened directory
+	FsBurnBytes uint64
+	// Bytes to read from wasi:random
+	RandomBurnBytes uint64
+	// Number of times to read the monotonic clock
+	ClockPolls uint64
+	// Number of outgoing wasi:http requests to make before answering
+	HttpCalls uint32
+	// URL of the outgoing requests
+	HttpUrl string
+	// Size of the outgoing request bodies, requests without a body are GETs
+	HttpBodySize uint64
 }
 
 func (v *Packet) String() string { return "Packet" }
 
 func (v *Packet) WriteToIndex(w wrpc.ByteWriter) (func(wrpc.IndexWriter) error, error) {
-	writes := make(map[uint32]func(wrpc.IndexWriter) error, 5)
+	writes := make(map[uint32]func(wrpc.IndexWriter) error, 18)
 	slog.Debug("writing field", "name", "id")
 	write0, err := (func(wrpc.IndexWriter) error)(nil), func(v string, w io.Writer) (err error) {
 		n := len(v)
@@ -126,22 +153,51 @@ func (v *Packet) WriteToIndex(w wrpc.ByteWriter) (func(wrpc.IndexWriter) error,
 	if write1 != nil {
 		writes[1] = write1
 	}
-	slog.Debug("writing field", "name", "mem-burn-mb")
+	slog.Debug("writing field", "name", "sent-at-ns")
 	write2, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
 		b := make([]byte, binary.MaxVarintLen64)
 		i := binary.PutUvarint(b, uint64(v))
 		slog.Debug("writing u64")
 		_, err = w.Write(b[:i])
 		return err
-	}(v.MemBurnMb, w)
+	}(v.SentAtNs, w)
 	if err != nil {
-		return nil, fmt.Errorf("failed to write `mem-burn-mb` field: %w", err)
+		return nil, fmt.Errorf("failed to write `sent-at-ns` field: %w", err)
 	}
 	if write2 != nil {
 		writes[2] = write2
 	}
+	slog.Debug("writing field", "name", "echo")
+	write3, err := (func(wrpc.IndexWriter) error)(nil), func(v bool, w io.ByteWriter) error {
+		if !v {
+			slog.Debug("writing `false` byte")
+			return w.WriteByte(0)
+		}
+		slog.Debug("writing `true` byte")
+		return w.WriteByte(1)
+	}(v.Echo, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `echo` field: %w", err)
+	}
+	if write3 != nil {
+		writes[3] = write3
+	}
+	slog.Debug("writing field", "name", "mem-burn-mb")
+	write4, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.MemBurnMb, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `mem-burn-mb` field: %w", err)
+	}
+	if write4 != nil {
+		writes[4] = write4
+	}
 	slog.Debug("writing field", "name", "cpu-burn-ms")
-	write3, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+	write5, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
 		b := make([]byte, binary.MaxVarintLen64)
 		i := binary.PutUvarint(b, uint64(v))
 		slog.Debug("writing u64")
@@ -151,11 +207,11 @@ func (v *Packet) WriteToIndex(w wrpc.ByteWriter) (func(wrpc.IndexWriter) error,
 	if err != nil {
 		return nil, fmt.Errorf("failed to write `cpu-burn-ms` field: %w", err)
 	}
-	if write3 != nil {
-		writes[3] = write3
+	if write5 != nil {
+		writes[5] = write5
 	}
 	slog.Debug("writing field", "name", "wait-ms")
-	write4, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+	write6, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
 		b := make([]byte, binary.MaxVarintLen64)
 		i := binary.PutUvarint(b, uint64(v))
 		slog.Debug("writing u64")
@@ -165,8 +221,178 @@ func (v *Packet) WriteToIndex(w wrpc.ByteWriter) (func(wrpc.IndexWriter) error,
 	if err != nil {
 		return nil, fmt.Errorf("failed to write `wait-ms` field: %w", err)
 	}
-	if write4 != nil {
-		writes[4] = write4
+	if write6 != nil {
+		writes[6] = write6
+	}
+	slog.Debug("writing field", "name", "fail-probability")
+	write7, err := (func(wrpc.IndexWriter) error)(nil), func(v float64, w io.Writer) (err error) {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+		slog.Debug("writing f64")
+		_, err = w.Write(b)
+		return err
+	}(v.FailProbability, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `fail-probability` field: %w", err)
+	}
+	if write7 != nil {
+		writes[7] = write7
+	}
+	slog.Debug("writing field", "name", "trap")
+	write8, err := (func(wrpc.IndexWriter) error)(nil), func(v bool, w io.ByteWriter) error {
+		if !v {
+			slog.Debug("writing `false` byte")
+			return w.WriteByte(0)
+		}
+		slog.Debug("writing `true` byte")
+		return w.WriteByte(1)
+	}(v.Trap, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `trap` field: %w", err)
+	}
+	if write8 != nil {
+		writes[8] = write8
+	}
+	slog.Debug("writing field", "name", "exceed-memory")
+	write9, err := (func(wrpc.IndexWriter) error)(nil), func(v bool, w io.ByteWriter) error {
+		if !v {
+			slog.Debug("writing `false` byte")
+			return w.WriteByte(0)
+		}
+		slog.Debug("writing `true` byte")
+		return w.WriteByte(1)
+	}(v.ExceedMemory, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `exceed-memory` field: %w", err)
+	}
+	if write9 != nil {
+		writes[9] = write9
+	}
+	slog.Debug("writing field", "name", "hops")
+	write10, err := (func(wrpc.IndexWriter) error)(nil), func(v uint32, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen32)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u32")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.Hops, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `hops` field: %w", err)
+	}
+	if write10 != nil {
+		writes[10] = write10
+	}
+	slog.Debug("writing field", "name", "fanout")
+	write11, err := (func(wrpc.IndexWriter) error)(nil), func(v uint32, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen32)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u32")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.Fanout, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `fanout` field: %w", err)
+	}
+	if write11 != nil {
+		writes[11] = write11
+	}
+	slog.Debug("writing field", "name", "fs-burn-bytes")
+	write12, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.FsBurnBytes, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `fs-burn-bytes` field: %w", err)
+	}
+	if write12 != nil {
+		writes[12] = write12
+	}
+	slog.Debug("writing field", "name", "random-burn-bytes")
+	write13, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.RandomBurnBytes, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `random-burn-bytes` field: %w", err)
+	}
+	if write13 != nil {
+		writes[13] = write13
+	}
+	slog.Debug("writing field", "name", "clock-polls")
+	write14, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.ClockPolls, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `clock-polls` field: %w", err)
+	}
+	if write14 != nil {
+		writes[14] = write14
+	}
+	slog.Debug("writing field", "name", "http-calls")
+	write15, err := (func(wrpc.IndexWriter) error)(nil), func(v uint32, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen32)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u32")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.HttpCalls, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `http-calls` field: %w", err)
+	}
+	if write15 != nil {
+		writes[15] = write15
+	}
+	slog.Debug("writing field", "name", "http-url")
+	write16, err := (func(wrpc.IndexWriter) error)(nil), func(v string, w io.Writer) (err error) {
+		n := len(v)
+		if n > math.MaxUint32 {
+			return fmt.Errorf("string byte length of %d overflows a 32-bit integer", n)
+		}
+		if err = func(v int, w io.Writer) error {
+			b := make([]byte, binary.MaxVarintLen32)
+			i := binary.PutUvarint(b, uint64(v))
+			slog.Debug("writing string byte length", "len", n)
+			_, err = w.Write(b[:i])
+			return err
+		}(n, w); err != nil {
+			return fmt.Errorf("failed to write string byte length of %d: %w", n, err)
+		}
+		slog.Debug("writing string bytes")
+		_, err = w.Write([]byte(v))
+		if err != nil {
+			return fmt.Errorf("failed to write string bytes: %w", err)
+		}
+		return nil
+	}(v.HttpUrl, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `http-url` field: %w", err)
+	}
+	if write16 != nil {
+		writes[16] = write16
+	}
+	slog.Debug("writing field", "name", "http-body-size")
+	write17, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.HttpBodySize, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `http-body-size` field: %w", err)
+	}
+	if write17 != nil {
+		writes[17] = write17
 	}
 
 	if len(writes) > 0 {
@@ -197,31 +423,1434 @@ func (v *Packet) WriteToIndex(w wrpc.ByteWriter) (func(wrpc.IndexWriter) error,
 	}
 	return nil, nil
 }
-func Blast(ctx__ context.Context, wrpc__ wrpc.Invoker, packet *Packet) (err__ error) {
-	var buf__ bytes.Buffer
-	write0__, err__ := (packet).WriteToIndex(&buf__)
-	if err__ != nil {
-		err__ = fmt.Errorf("failed to write `packet` parameter: %w", err__)
-		return
+
+type Hop struct {
+	// Depth of the hop in the call chain, the first downstream blaster is at depth 1
+	Depth uint32
+	// Wall clock time the packet was received, in nanoseconds since the unix epoch
+	ReceivedAtNs uint64
+	// Wall clock time the component finished working on the packet, in nanoseconds since the unix epoch
+	FinishedAtNs uint64
+}
+
+func (v *Hop) String() string { return "Hop" }
+
+func (v *Hop) WriteToIndex(w wrpc.ByteWriter) (func(wrpc.IndexWriter) error, error) {
+	writes := make(map[uint32]func(wrpc.IndexWriter) error, 3)
+	slog.Debug("writing field", "name", "depth")
+	write0, err := (func(wrpc.IndexWriter) error)(nil), func(v uint32, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen32)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u32")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.Depth, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `depth` field: %w", err)
 	}
-	if write0__ != nil {
-		err__ = errors.New("unexpected deferred write for synchronous `packet` parameter")
-		return
+	if write0 != nil {
+		writes[0] = write0
 	}
-	var w__ wrpc.IndexWriteCloser
-	var r__ wrpc.IndexReadCloser
-	w__, r__, err__ = wrpc__.Invoke(ctx__, "xk6:wrpc/blaster@0.0.1", "blast", buf__.Bytes())
-	if err__ != nil {
-		err__ = fmt.Errorf("failed to invoke `blast`: %w", err__)
-		return
+	slog.Debug("writing field", "name", "received-at-ns")
+	write1, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.ReceivedAtNs, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `received-at-ns` field: %w", err)
 	}
-	defer func() {
-		if err := r__.Close(); err != nil {
-			slog.ErrorContext(ctx__, "failed to close reader", "instance", "xk6:wrpc/blaster@0.0.1", "name", "blast", "err", err)
-		}
-	}()
-	if cErr__ := w__.Close(); cErr__ != nil {
-		slog.DebugContext(ctx__, "failed to close outgoing stream", "instance", "xk6:wrpc/blaster@0.0.1", "name", "blast", "err", cErr__)
+	if write1 != nil {
+		writes[1] = write1
+	}
+	slog.Debug("writing field", "name", "finished-at-ns")
+	write2, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.FinishedAtNs, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `finished-at-ns` field: %w", err)
+	}
+	if write2 != nil {
+		writes[2] = write2
+	}
+
+	if len(writes) > 0 {
+		return func(w wrpc.IndexWriter) error {
+			var wg sync.WaitGroup
+			var wgErr atomic.Value
+			for index, write := range writes {
+				wg.Add(1)
+				w, err := w.Index(index)
+				if err != nil {
+					return fmt.Errorf("failed to index nested record writer: %w", err)
+				}
+				write := write
+				go func() {
+					defer wg.Done()
+					if err := write(w); err != nil {
+						wgErr.Store(err)
+					}
+				}()
+			}
+			wg.Wait()
+			err := wgErr.Load()
+			if err == nil {
+				return nil
+			}
+			return err.(error)
+		}, nil
+	}
+	return nil, nil
+}
+
+type HttpCall struct {
+	// Status code of the response
+	Status uint16
+	// Bytes read from the response body
+	ResponseSize uint64
+	// Time from sending the request to reading the whole response, in nanoseconds
+	DurationNs uint64
+}
+
+func (v *HttpCall) String() string { return "HttpCall" }
+
+func (v *HttpCall) WriteToIndex(w wrpc.ByteWriter) (func(wrpc.IndexWriter) error, error) {
+	writes := make(map[uint32]func(wrpc.IndexWriter) error, 3)
+	slog.Debug("writing field", "name", "status")
+	write0, err := (func(wrpc.IndexWriter) error)(nil), func(v uint16, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen16)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u16")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.Status, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `status` field: %w", err)
+	}
+	if write0 != nil {
+		writes[0] = write0
+	}
+	slog.Debug("writing field", "name", "response-size")
+	write1, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.ResponseSize, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `response-size` field: %w", err)
+	}
+	if write1 != nil {
+		writes[1] = write1
+	}
+	slog.Debug("writing field", "name", "duration-ns")
+	write2, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.DurationNs, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `duration-ns` field: %w", err)
+	}
+	if write2 != nil {
+		writes[2] = write2
+	}
+
+	if len(writes) > 0 {
+		return func(w wrpc.IndexWriter) error {
+			var wg sync.WaitGroup
+			var wgErr atomic.Value
+			for index, write := range writes {
+				wg.Add(1)
+				w, err := w.Index(index)
+				if err != nil {
+					return fmt.Errorf("failed to index nested record writer: %w", err)
+				}
+				write := write
+				go func() {
+					defer wg.Done()
+					if err := write(w); err != nil {
+						wgErr.Store(err)
+					}
+				}()
+			}
+			wg.Wait()
+			err := wgErr.Load()
+			if err == nil {
+				return nil
+			}
+			return err.(error)
+		}, nil
+	}
+	return nil, nil
+}
+
+type Response struct {
+	// The ID of the packet
+	Id string
+	// Random identifier of the component instance that handled the packet
+	InstanceId string
+	// Number of packets handled by this instance, including this one
+	Invocation uint64
+	// Wall clock time the packet was received, in nanoseconds since the unix epoch
+	ReceivedAtNs uint64
+	// Wall clock time the component finished working on the packet, in nanoseconds since the unix epoch
+	FinishedAtNs uint64
+	// Time actually spent spinning the CPU, in nanoseconds
+	CpuTimeNs uint64
+	// Time spent on filesystem, random and clock work, in nanoseconds
+	IoTimeNs uint64
+	// Bytes of memory allocated
+	MemAllocatedBytes uint64
+	// Length of the received payload
+	PayloadLen uint64
+	// CRC-32 (IEEE) checksum of the received payload
+	PayloadChecksum uint32
+	// The received payload, when echo was set
+	Payload []uint8
+	// Every downstream hop the packet went through
+	Downstream []*Hop
+	// Every outgoing HTTP request made by the component
+	HttpCalls []*HttpCall
+}
+
+func (v *Response) String() string { return "Response" }
+
+func (v *Response) WriteToIndex(w wrpc.ByteWriter) (func(wrpc.IndexWriter) error, error) {
+	writes := make(map[uint32]func(wrpc.IndexWriter) error, 13)
+	slog.Debug("writing field", "name", "id")
+	write0, err := (func(wrpc.IndexWriter) error)(nil), func(v string, w io.Writer) (err error) {
+		n := len(v)
+		if n > math.MaxUint32 {
+			return fmt.Errorf("string byte length of %d overflows a 32-bit integer", n)
+		}
+		if err = func(v int, w io.Writer) error {
+			b := make([]byte, binary.MaxVarintLen32)
+			i := binary.PutUvarint(b, uint64(v))
+			slog.Debug("writing string byte length", "len", n)
+			_, err = w.Write(b[:i])
+			return err
+		}(n, w); err != nil {
+			return fmt.Errorf("failed to write string byte length of %d: %w", n, err)
+		}
+		slog.Debug("writing string bytes")
+		_, err = w.Write([]byte(v))
+		if err != nil {
+			return fmt.Errorf("failed to write string bytes: %w", err)
+		}
+		return nil
+	}(v.Id, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `id` field: %w", err)
+	}
+	if write0 != nil {
+		writes[0] = write0
+	}
+	slog.Debug("writing field", "name", "instance-id")
+	write1, err := (func(wrpc.IndexWriter) error)(nil), func(v string, w io.Writer) (err error) {
+		n := len(v)
+		if n > math.MaxUint32 {
+			return fmt.Errorf("string byte length of %d overflows a 32-bit integer", n)
+		}
+		if err = func(v int, w io.Writer) error {
+			b := make([]byte, binary.MaxVarintLen32)
+			i := binary.PutUvarint(b, uint64(v))
+			slog.Debug("writing string byte length", "len", n)
+			_, err = w.Write(b[:i])
+			return err
+		}(n, w); err != nil {
+			return fmt.Errorf("failed to write string byte length of %d: %w", n, err)
+		}
+		slog.Debug("writing string bytes")
+		_, err = w.Write([]byte(v))
+		if err != nil {
+			return fmt.Errorf("failed to write string bytes: %w", err)
+		}
+		return nil
+	}(v.InstanceId, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `instance-id` field: %w", err)
+	}
+	if write1 != nil {
+		writes[1] = write1
+	}
+	slog.Debug("writing field", "name", "invocation")
+	write2, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.Invocation, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `invocation` field: %w", err)
+	}
+	if write2 != nil {
+		writes[2] = write2
+	}
+	slog.Debug("writing field", "name", "received-at-ns")
+	write3, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.ReceivedAtNs, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `received-at-ns` field: %w", err)
+	}
+	if write3 != nil {
+		writes[3] = write3
+	}
+	slog.Debug("writing field", "name", "finished-at-ns")
+	write4, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.FinishedAtNs, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `finished-at-ns` field: %w", err)
+	}
+	if write4 != nil {
+		writes[4] = write4
+	}
+	slog.Debug("writing field", "name", "cpu-time-ns")
+	write5, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.CpuTimeNs, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `cpu-time-ns` field: %w", err)
+	}
+	if write5 != nil {
+		writes[5] = write5
+	}
+	slog.Debug("writing field", "name", "io-time-ns")
+	write6, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.IoTimeNs, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `io-time-ns` field: %w", err)
+	}
+	if write6 != nil {
+		writes[6] = write6
+	}
+	slog.Debug("writing field", "name", "mem-allocated-bytes")
+	write7, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.MemAllocatedBytes, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `mem-allocated-bytes` field: %w", err)
+	}
+	if write7 != nil {
+		writes[7] = write7
+	}
+	slog.Debug("writing field", "name", "payload-len")
+	write8, err := (func(wrpc.IndexWriter) error)(nil), func(v uint64, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen64)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u64")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.PayloadLen, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `payload-len` field: %w", err)
+	}
+	if write8 != nil {
+		writes[8] = write8
+	}
+	slog.Debug("writing field", "name", "payload-checksum")
+	write9, err := (func(wrpc.IndexWriter) error)(nil), func(v uint32, w io.Writer) (err error) {
+		b := make([]byte, binary.MaxVarintLen32)
+		i := binary.PutUvarint(b, uint64(v))
+		slog.Debug("writing u32")
+		_, err = w.Write(b[:i])
+		return err
+	}(v.PayloadChecksum, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `payload-checksum` field: %w", err)
+	}
+	if write9 != nil {
+		writes[9] = write9
+	}
+	slog.Debug("writing field", "name", "payload")
+	write10, err := func(v []uint8, w interface {
+		io.ByteWriter
+		io.Writer
+	}) (write func(wrpc.IndexWriter) error, err error) {
+		n := len(v)
+		if n > math.MaxUint32 {
+			return nil, fmt.Errorf("list length of %d overflows a 32-bit integer", n)
+		}
+		if err = func(v int, w io.Writer) error {
+			b := make([]byte, binary.MaxVarintLen32)
+			i := binary.PutUvarint(b, uint64(v))
+			slog.Debug("writing list length", "len", n)
+			_, err = w.Write(b[:i])
+			return err
+		}(n, w); err != nil {
+			return nil, fmt.Errorf("failed to write list length of %d: %w", n, err)
+		}
+		slog.Debug("writing list elements")
+		writes := make(map[uint32]func(wrpc.IndexWriter) error, n)
+		for i, e := range v {
+			write, err := (func(wrpc.IndexWriter) error)(nil), func(v uint8, w io.ByteWriter) error {
+				slog.Debug("writing u8 byte")
+				return w.WriteByte(v)
+			}(e, w)
+			if err != nil {
+				return nil, fmt.Errorf("failed to write list element %d: %w", i, err)
+			}
+			if write != nil {
+				writes[uint32(i)] = write
+			}
+		}
+		if len(writes) > 0 {
+			return func(w wrpc.IndexWriter) error {
+				var wg sync.WaitGroup
+				var wgErr atomic.Value
+				for index, write := range writes {
+					wg.Add(1)
+					w, err := w.Index(index)
+					if err != nil {
+						return fmt.Errorf("failed to index nested list writer: %w", err)
+					}
+					write := write
+					go func() {
+						defer wg.Done()
+						if err := write(w); err != nil {
+							wgErr.Store(err)
+						}
+					}()
+				}
+				wg.Wait()
+				err := wgErr.Load()
+				if err == nil {
+					return nil
+				}
+				return err.(error)
+			}, nil
+		}
+		return nil, nil
+	}(v.Payload, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `payload` field: %w", err)
+	}
+	if write10 != nil {
+		writes[10] = write10
+	}
+	slog.Debug("writing field", "name", "downstream")
+	write11, err := func(v []*Hop, w interface {
+		io.ByteWriter
+		io.Writer
+	}) (write func(wrpc.IndexWriter) error, err error) {
+		n := len(v)
+		if n > math.MaxUint32 {
+			return nil, fmt.Errorf("list length of %d overflows a 32-bit integer", n)
+		}
+		if err = func(v int, w io.Writer) error {
+			b := make([]byte, binary.MaxVarintLen32)
+			i := binary.PutUvarint(b, uint64(v))
+			slog.Debug("writing list length", "len", n)
+			_, err = w.Write(b[:i])
+			return err
+		}(n, w); err != nil {
+			return nil, fmt.Errorf("failed to write list length of %d: %w", n, err)
+		}
+		slog.Debug("writing list elements")
+		writes := make(map[uint32]func(wrpc.IndexWriter) error, n)
+		for i, e := range v {
+			write, err := (e).WriteToIndex(w)
+			if err != nil {
+				return nil, fmt.Errorf("failed to write list element %d: %w", i, err)
+			}
+			if write != nil {
+				writes[uint32(i)] = write
+			}
+		}
+		if len(writes) > 0 {
+			return func(w wrpc.IndexWriter) error {
+				var wg sync.WaitGroup
+				var wgErr atomic.Value
+				for index, write := range writes {
+					wg.Add(1)
+					w, err := w.Index(index)
+					if err != nil {
+						return fmt.Errorf("failed to index nested list writer: %w", err)
+					}
+					write := write
+					go func() {
+						defer wg.Done()
+						if err := write(w); err != nil {
+							wgErr.Store(err)
+						}
+					}()
+				}
+				wg.Wait()
+				err := wgErr.Load()
+				if err == nil {
+					return nil
+				}
+				return err.(error)
+			}, nil
+		}
+		return nil, nil
+	}(v.Downstream, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `downstream` field: %w", err)
+	}
+	if write11 != nil {
+		writes[11] = write11
+	}
+	slog.Debug("writing field", "name", "http-calls")
+	write12, err := func(v []*HttpCall, w interface {
+		io.ByteWriter
+		io.Writer
+	}) (write func(wrpc.IndexWriter) error, err error) {
+		n := len(v)
+		if n > math.MaxUint32 {
+			return nil, fmt.Errorf("list length of %d overflows a 32-bit integer", n)
+		}
+		if err = func(v int, w io.Writer) error {
+			b := make([]byte, binary.MaxVarintLen32)
+			i := binary.PutUvarint(b, uint64(v))
+			slog.Debug("writing list length", "len", n)
+			_, err = w.Write(b[:i])
+			return err
+		}(n, w); err != nil {
+			return nil, fmt.Errorf("failed to write list length of %d: %w", n, err)
+		}
+		slog.Debug("writing list elements")
+		writes := make(map[uint32]func(wrpc.IndexWriter) error, n)
+		for i, e := range v {
+			write, err := (e).WriteToIndex(w)
+			if err != nil {
+				return nil, fmt.Errorf("failed to write list element %d: %w", i, err)
+			}
+			if write != nil {
+				writes[uint32(i)] = write
+			}
+		}
+		if len(writes) > 0 {
+			return func(w wrpc.IndexWriter) error {
+				var wg sync.WaitGroup
+				var wgErr atomic.Value
+				for index, write := range writes {
+					wg.Add(1)
+					w, err := w.Index(index)
+					if err != nil {
+						return fmt.Errorf("failed to index nested list writer: %w", err)
+					}
+					write := write
+					go func() {
+						defer wg.Done()
+						if err := write(w); err != nil {
+							wgErr.Store(err)
+						}
+					}()
+				}
+				wg.Wait()
+				err := wgErr.Load()
+				if err == nil {
+					return nil
+				}
+				return err.(error)
+			}, nil
+		}
+		return nil, nil
+	}(v.HttpCalls, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `http-calls` field: %w", err)
+	}
+	if write12 != nil {
+		writes[12] = write12
+	}
+
+	if len(writes) > 0 {
+		return func(w wrpc.IndexWriter) error {
+			var wg sync.WaitGroup
+			var wgErr atomic.Value
+			for index, write := range writes {
+				wg.Add(1)
+				w, err := w.Index(index)
+				if err != nil {
+					return fmt.Errorf("failed to index nested record writer: %w", err)
+				}
+				write := write
+				go func() {
+					defer wg.Done()
+					if err := write(w); err != nil {
+						wgErr.Store(err)
+					}
+				}()
+			}
+			wg.Wait()
+			err := wgErr.Load()
+			if err == nil {
+				return nil
+			}
+			return err.(error)
+		}, nil
+	}
+	return nil, nil
+}
+
+type Error struct {
+	// The ID of the packet
+	Id string
+	// Why the component failed
+	Message string
+}
+
+func (v *Error) String() string { return "Error" }
+
+func (v *Error) WriteToIndex(w wrpc.ByteWriter) (func(wrpc.IndexWriter) error, error) {
+	writes := make(map[uint32]func(wrpc.IndexWriter) error, 2)
+	slog.Debug("writing field", "name", "id")
+	write0, err := (func(wrpc.IndexWriter) error)(nil), func(v string, w io.Writer) (err error) {
+		n := len(v)
+		if n > math.MaxUint32 {
+			return fmt.Errorf("string byte length of %d overflows a 32-bit integer", n)
+		}
+		if err = func(v int, w io.Writer) error {
+			b := make([]byte, binary.MaxVarintLen32)
+			i := binary.PutUvarint(b, uint64(v))
+			slog.Debug("writing string byte length", "len", n)
+			_, err = w.Write(b[:i])
+			return err
+		}(n, w); err != nil {
+			return fmt.Errorf("failed to write string byte length of %d: %w", n, err)
+		}
+		slog.Debug("writing string bytes")
+		_, err = w.Write([]byte(v))
+		if err != nil {
+			return fmt.Errorf("failed to write string bytes: %w", err)
+		}
+		return nil
+	}(v.Id, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `id` field: %w", err)
+	}
+	if write0 != nil {
+		writes[0] = write0
+	}
+	slog.Debug("writing field", "name", "message")
+	write1, err := (func(wrpc.IndexWriter) error)(nil), func(v string, w io.Writer) (err error) {
+		n := len(v)
+		if n > math.MaxUint32 {
+			return fmt.Errorf("string byte length of %d overflows a 32-bit integer", n)
+		}
+		if err = func(v int, w io.Writer) error {
+			b := make([]byte, binary.MaxVarintLen32)
+			i := binary.PutUvarint(b, uint64(v))
+			slog.Debug("writing string byte length", "len", n)
+			_, err = w.Write(b[:i])
+			return err
+		}(n, w); err != nil {
+			return fmt.Errorf("failed to write string byte length of %d: %w", n, err)
+		}
+		slog.Debug("writing string bytes")
+		_, err = w.Write([]byte(v))
+		if err != nil {
+			return fmt.Errorf("failed to write string bytes: %w", err)
+		}
+		return nil
+	}(v.Message, w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write `message` field: %w", err)
+	}
+	if write1 != nil {
+		writes[1] = write1
+	}
+
+	if len(writes) > 0 {
+		return func(w wrpc.IndexWriter) error {
+			var wg sync.WaitGroup
+			var wgErr atomic.Value
+			for index, write := range writes {
+				wg.Add(1)
+				w, err := w.Index(index)
+				if err != nil {
+					return fmt.Errorf("failed to index nested record writer: %w", err)
+				}
+				write := write
+				go func() {
+					defer wg.Done()
+					if err := write(w); err != nil {
+						wgErr.Store(err)
+					}
+				}()
+			}
+			wg.Wait()
+			err := wgErr.Load()
+			if err == nil {
+				return nil
+			}
+			return err.(error)
+		}, nil
+	}
+	return nil, nil
+}
+func Blast(ctx__ context.Context, wrpc__ wrpc.Invoker, packet *Packet) (r0__ *wrpc.Result[Response, Error], err__ error) {
+	var buf__ bytes.Buffer
+	write0__, err__ := (packet).WriteToIndex(&buf__)
+	if err__ != nil {
+		err__ = fmt.Errorf("failed to write `packet` parameter: %w", err__)
+		return
+	}
+	if write0__ != nil {
+		err__ = errors.New("unexpected deferred write for synchronous `packet` parameter")
+		return
+	}
+	var w__ wrpc.IndexWriteCloser
+	var r__ wrpc.IndexReadCloser
+	w__, r__, err__ = wrpc__.Invoke(ctx__, "xk6:wrpc/blaster@0.0.1", "blast", buf__.Bytes())
+	if err__ != nil {
+		err__ = fmt.Errorf("failed to invoke `blast`: %w", err__)
+		return
+	}
+	defer func() {
+		if err := r__.Close(); err != nil {
+			slog.ErrorContext(ctx__, "failed to close reader", "instance", "xk6:wrpc/blaster@0.0.1", "name", "blast", "err", err)
+		}
+	}()
+	if cErr__ := w__.Close(); cErr__ != nil {
+		slog.DebugContext(ctx__, "failed to close outgoing stream", "instance", "xk6:wrpc/blaster@0.0.1", "name", "blast", "err", cErr__)
+	}
+	r0__, err__ = func(r wrpc.IndexReadCloser, path ...uint32) (*wrpc.Result[Response, Error], error) {
+		slog.Debug("reading result status byte")
+		status, err := r.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read result status byte: %w", err)
+		}
+		switch status {
+		case 0:
+			slog.Debug("reading `result::ok` payload")
+			v, err := func(r wrpc.IndexReadCloser, path ...uint32) (*Response, error) {
+				v := &Response{}
+				var err error
+				slog.Debug("reading field", "name", "id")
+				v.Id, err = func(r interface {
+					io.ByteReader
+					io.Reader
+				}) (string, error) {
+					var x uint32
+					var s uint8
+					for i := 0; i < 5; i++ {
+						slog.Debug("reading string length byte", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return "", fmt.Errorf("failed to read string length byte: %w", err)
+						}
+						if s == 28 && b > 0x0f {
+							return "", errors.New("string length overflows a 32-bit integer")
+						}
+						if b < 0x80 {
+							x = x | uint32(b)<<s
+							if x == 0 {
+								return "", nil
+							}
+							buf := make([]byte, x)
+							slog.Debug("reading string bytes", "len", x)
+							_, err = r.Read(buf)
+							if err != nil {
+								return "", fmt.Errorf("failed to read string bytes: %w", err)
+							}
+							if !utf8.Valid(buf) {
+								return string(buf), errors.New("string is not valid UTF-8")
+							}
+							return string(buf), nil
+						}
+						x |= uint32(b&0x7f) << s
+						s += 7
+					}
+					return "", errors.New("string length overflows a 32-bit integer")
+				}(r)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `id` field: %w", err)
+				}
+				slog.Debug("reading field", "name", "instance-id")
+				v.InstanceId, err = func(r interface {
+					io.ByteReader
+					io.Reader
+				}) (string, error) {
+					var x uint32
+					var s uint8
+					for i := 0; i < 5; i++ {
+						slog.Debug("reading string length byte", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return "", fmt.Errorf("failed to read string length byte: %w", err)
+						}
+						if s == 28 && b > 0x0f {
+							return "", errors.New("string length overflows a 32-bit integer")
+						}
+						if b < 0x80 {
+							x = x | uint32(b)<<s
+							if x == 0 {
+								return "", nil
+							}
+							buf := make([]byte, x)
+							slog.Debug("reading string bytes", "len", x)
+							_, err = r.Read(buf)
+							if err != nil {
+								return "", fmt.Errorf("failed to read string bytes: %w", err)
+							}
+							if !utf8.Valid(buf) {
+								return string(buf), errors.New("string is not valid UTF-8")
+							}
+							return string(buf), nil
+						}
+						x |= uint32(b&0x7f) << s
+						s += 7
+					}
+					return "", errors.New("string length overflows a 32-bit integer")
+				}(r)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `instance-id` field: %w", err)
+				}
+				slog.Debug("reading field", "name", "invocation")
+				v.Invocation, err = func(r io.ByteReader) (uint64, error) {
+					var x uint64
+					var s uint8
+					for i := 0; i < 10; i++ {
+						slog.Debug("reading u64 byte", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return x, fmt.Errorf("failed to read u64 byte: %w", err)
+						}
+						if s == 63 && b > 0x01 {
+							return x, errors.New("varint overflows a 64-bit integer")
+						}
+						if b < 0x80 {
+							return x | uint64(b)<<s, nil
+						}
+						x |= uint64(b&0x7f) << s
+						s += 7
+					}
+					return x, errors.New("varint overflows a 64-bit integer")
+				}(r)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `invocation` field: %w", err)
+				}
+				slog.Debug("reading field", "name", "received-at-ns")
+				v.ReceivedAtNs, err = func(r io.ByteReader) (uint64, error) {
+					var x uint64
+					var s uint8
+					for i := 0; i < 10; i++ {
+						slog.Debug("reading u64 byte", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return x, fmt.Errorf("failed to read u64 byte: %w", err)
+						}
+						if s == 63 && b > 0x01 {
+							return x, errors.New("varint overflows a 64-bit integer")
+						}
+						if b < 0x80 {
+							return x | uint64(b)<<s, nil
+						}
+						x |= uint64(b&0x7f) << s
+						s += 7
+					}
+					return x, errors.New("varint overflows a 64-bit integer")
+				}(r)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `received-at-ns` field: %w", err)
+				}
+				slog.Debug("reading field", "name", "finished-at-ns")
+				v.FinishedAtNs, err = func(r io.ByteReader) (uint64, error) {
+					var x uint64
+					var s uint8
+					for i := 0; i < 10; i++ {
+						slog.Debug("reading u64 byte", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return x, fmt.Errorf("failed to read u64 byte: %w", err)
+						}
+						if s == 63 && b > 0x01 {
+							return x, errors.New("varint overflows a 64-bit integer")
+						}
+						if b < 0x80 {
+							return x | uint64(b)<<s, nil
+						}
+						x |= uint64(b&0x7f) << s
+						s += 7
+					}
+					return x, errors.New("varint overflows a 64-bit integer")
+				}(r)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `finished-at-ns` field: %w", err)
+				}
+				slog.Debug("reading field", "name", "cpu-time-ns")
+				v.CpuTimeNs, err = func(r io.ByteReader) (uint64, error) {
+					var x uint64
+					var s uint8
+					for i := 0; i < 10; i++ {
+						slog.Debug("reading u64 byte", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return x, fmt.Errorf("failed to read u64 byte: %w", err)
+						}
+						if s == 63 && b > 0x01 {
+							return x, errors.New("varint overflows a 64-bit integer")
+						}
+						if b < 0x80 {
+							return x | uint64(b)<<s, nil
+						}
+						x |= uint64(b&0x7f) << s
+						s += 7
+					}
+					return x, errors.New("varint overflows a 64-bit integer")
+				}(r)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `cpu-time-ns` field: %w", err)
+				}
+				slog.Debug("reading field", "name", "io-time-ns")
+				v.IoTimeNs, err = func(r io.ByteReader) (uint64, error) {
+					var x uint64
+					var s uint8
+					for i := 0; i < 10; i++ {
+						slog.Debug("reading u64 byte", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return x, fmt.Errorf("failed to read u64 byte: %w", err)
+						}
+						if s == 63 && b > 0x01 {
+							return x, errors.New("varint overflows a 64-bit integer")
+						}
+						if b < 0x80 {
+							return x | uint64(b)<<s, nil
+						}
+						x |= uint64(b&0x7f) << s
+						s += 7
+					}
+					return x, errors.New("varint overflows a 64-bit integer")
+				}(r)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `io-time-ns` field: %w", err)
+				}
+				slog.Debug("reading field", "name", "mem-allocated-bytes")
+				v.MemAllocatedBytes, err = func(r io.ByteReader) (uint64, error) {
+					var x uint64
+					var s uint8
+					for i := 0; i < 10; i++ {
+						slog.Debug("reading u64 byte", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return x, fmt.Errorf("failed to read u64 byte: %w", err)
+						}
+						if s == 63 && b > 0x01 {
+							return x, errors.New("varint overflows a 64-bit integer")
+						}
+						if b < 0x80 {
+							return x | uint64(b)<<s, nil
+						}
+						x |= uint64(b&0x7f) << s
+						s += 7
+					}
+					return x, errors.New("varint overflows a 64-bit integer")
+				}(r)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `mem-allocated-bytes` field: %w", err)
+				}
+				slog.Debug("reading field", "name", "payload-len")
+				v.PayloadLen, err = func(r io.ByteReader) (uint64, error) {
+					var x uint64
+					var s uint8
+					for i := 0; i < 10; i++ {
+						slog.Debug("reading u64 byte", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return x, fmt.Errorf("failed to read u64 byte: %w", err)
+						}
+						if s == 63 && b > 0x01 {
+							return x, errors.New("varint overflows a 64-bit integer")
+						}
+						if b < 0x80 {
+							return x | uint64(b)<<s, nil
+						}
+						x |= uint64(b&0x7f) << s
+						s += 7
+					}
+					return x, errors.New("varint overflows a 64-bit integer")
+				}(r)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `payload-len` field: %w", err)
+				}
+				slog.Debug("reading field", "name", "payload-checksum")
+				v.PayloadChecksum, err = func(r io.ByteReader) (uint32, error) {
+					var x uint32
+					var s uint8
+					for i := 0; i < 5; i++ {
+						slog.Debug("reading u32 byte", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return x, fmt.Errorf("failed to read u32 byte: %w", err)
+						}
+						if s == 28 && b > 0x0f {
+							return x, errors.New("varint overflows a 32-bit integer")
+						}
+						if b < 0x80 {
+							return x | uint32(b)<<s, nil
+						}
+						x |= uint32(b&0x7f) << s
+						s += 7
+					}
+					return x, errors.New("varint overflows a 32-bit integer")
+				}(r)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `payload-checksum` field: %w", err)
+				}
+				slog.Debug("reading field", "name", "payload")
+				v.Payload, err = func(r interface {
+					io.ByteReader
+					io.Reader
+				}) ([]byte, error) {
+					var x uint32
+					var s uint
+					for i := 0; i < 5; i++ {
+						slog.Debug("reading byte list length", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return nil, fmt.Errorf("failed to read byte list length byte: %w", err)
+						}
+						if s == 28 && b > 0x0f {
+							return nil, errors.New("byte list length overflows a 32-bit integer")
+						}
+						if b < 0x80 {
+							x = x | uint32(b)<<s
+							if x == 0 {
+								return nil, nil
+							}
+							buf := make([]byte, x)
+							slog.Debug("reading byte list contents", "len", x)
+							_, err = io.ReadFull(r, buf)
+							if err != nil {
+								return nil, fmt.Errorf("failed to read byte list contents: %w", err)
+							}
+							return buf, nil
+						}
+						x |= uint32(b&0x7f) << s
+						s += 7
+					}
+					return nil, errors.New("byte length overflows a 32-bit integer")
+				}(r)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `payload` field: %w", err)
+				}
+				slog.Debug("reading field", "name", "downstream")
+				v.Downstream, err = func(r wrpc.IndexReadCloser, path ...uint32) ([]*Hop, error) {
+					var x uint32
+					var s uint
+					for i := 0; i < 5; i++ {
+						slog.Debug("reading list length byte", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return nil, fmt.Errorf("failed to read list length byte: %w", err)
+						}
+						if s == 28 && b > 0x0f {
+							return nil, errors.New("list length overflows a 32-bit integer")
+						}
+						if b < 0x80 {
+							x = x | uint32(b)<<s
+							if x == 0 {
+								return nil, nil
+							}
+							vs := make([]*Hop, x)
+							for i := range vs {
+								slog.Debug("reading list element", "i", i)
+								vs[i], err = func(r wrpc.IndexReadCloser, path ...uint32) (*Hop, error) {
+									v := &Hop{}
+									var err error
+									slog.Debug("reading field", "name", "depth")
+									v.Depth, err = func(r io.ByteReader) (uint32, error) {
+										var x uint32
+										var s uint8
+										for i := 0; i < 5; i++ {
+											slog.Debug("reading u32 byte", "i", i)
+											b, err := r.ReadByte()
+											if err != nil {
+												if i > 0 && err == io.EOF {
+													err = io.ErrUnexpectedEOF
+												}
+												return x, fmt.Errorf("failed to read u32 byte: %w", err)
+											}
+											if s == 28 && b > 0x0f {
+												return x, errors.New("varint overflows a 32-bit integer")
+											}
+											if b < 0x80 {
+												return x | uint32(b)<<s, nil
+											}
+											x |= uint32(b&0x7f) << s
+											s += 7
+										}
+										return x, errors.New("varint overflows a 32-bit integer")
+									}(r)
+									if err != nil {
+										return nil, fmt.Errorf("failed to read `depth` field: %w", err)
+									}
+									slog.Debug("reading field", "name", "received-at-ns")
+									v.ReceivedAtNs, err = func(r io.ByteReader) (uint64, error) {
+										var x uint64
+										var s uint8
+										for i := 0; i < 10; i++ {
+											slog.Debug("reading u64 byte", "i", i)
+											b, err := r.ReadByte()
+											if err != nil {
+												if i > 0 && err == io.EOF {
+													err = io.ErrUnexpectedEOF
+												}
+												return x, fmt.Errorf("failed to read u64 byte: %w", err)
+											}
+											if s == 63 && b > 0x01 {
+												return x, errors.New("varint overflows a 64-bit integer")
+											}
+											if b < 0x80 {
+												return x | uint64(b)<<s, nil
+											}
+											x |= uint64(b&0x7f) << s
+											s += 7
+										}
+										return x, errors.New("varint overflows a 64-bit integer")
+									}(r)
+									if err != nil {
+										return nil, fmt.Errorf("failed to read `received-at-ns` field: %w", err)
+									}
+									slog.Debug("reading field", "name", "finished-at-ns")
+									v.FinishedAtNs, err = func(r io.ByteReader) (uint64, error) {
+										var x uint64
+										var s uint8
+										for i := 0; i < 10; i++ {
+											slog.Debug("reading u64 byte", "i", i)
+											b, err := r.ReadByte()
+											if err != nil {
+												if i > 0 && err == io.EOF {
+													err = io.ErrUnexpectedEOF
+												}
+												return x, fmt.Errorf("failed to read u64 byte: %w", err)
+											}
+											if s == 63 && b > 0x01 {
+												return x, errors.New("varint overflows a 64-bit integer")
+											}
+											if b < 0x80 {
+												return x | uint64(b)<<s, nil
+											}
+											x |= uint64(b&0x7f) << s
+											s += 7
+										}
+										return x, errors.New("varint overflows a 64-bit integer")
+									}(r)
+									if err != nil {
+										return nil, fmt.Errorf("failed to read `finished-at-ns` field: %w", err)
+									}
+									return v, nil
+								}(r, append(path, uint32(i))...)
+								if err != nil {
+									return nil, fmt.Errorf("failed to read list element %d: %w", i, err)
+								}
+							}
+							return vs, nil
+						}
+						x |= uint32(b&0x7f) << s
+						s += 7
+					}
+					return nil, errors.New("list length overflows a 32-bit integer")
+				}(r, append(path, 11)...)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `downstream` field: %w", err)
+				}
+				slog.Debug("reading field", "name", "http-calls")
+				v.HttpCalls, err = func(r wrpc.IndexReadCloser, path ...uint32) ([]*HttpCall, error) {
+					var x uint32
+					var s uint
+					for i := 0; i < 5; i++ {
+						slog.Debug("reading list length byte", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return nil, fmt.Errorf("failed to read list length byte: %w", err)
+						}
+						if s == 28 && b > 0x0f {
+							return nil, errors.New("list length overflows a 32-bit integer")
+						}
+						if b < 0x80 {
+							x = x | uint32(b)<<s
+							if x == 0 {
+								return nil, nil
+							}
+							vs := make([]*HttpCall, x)
+							for i := range vs {
+								slog.Debug("reading list element", "i", i)
+								vs[i], err = func(r wrpc.IndexReadCloser, path ...uint32) (*HttpCall, error) {
+									v := &HttpCall{}
+									var err error
+									slog.Debug("reading field", "name", "status")
+									v.Status, err = func(r io.ByteReader) (uint16, error) {
+										var x uint16
+										var s uint8
+										for i := 0; i < 3; i++ {
+											slog.Debug("reading u16 byte", "i", i)
+											b, err := r.ReadByte()
+											if err != nil {
+												if i > 0 && err == io.EOF {
+													err = io.ErrUnexpectedEOF
+												}
+												return x, fmt.Errorf("failed to read u16 byte: %w", err)
+											}
+											if s == 14 && b > 0x03 {
+												return x, errors.New("varint overflows a 16-bit integer")
+											}
+											if b < 0x80 {
+												return x | uint16(b)<<s, nil
+											}
+											x |= uint16(b&0x7f) << s
+											s += 7
+										}
+										return x, errors.New("varint overflows a 16-bit integer")
+									}(r)
+									if err != nil {
+										return nil, fmt.Errorf("failed to read `status` field: %w", err)
+									}
+									slog.Debug("reading field", "name", "response-size")
+									v.ResponseSize, err = func(r io.ByteReader) (uint64, error) {
+										var x uint64
+										var s uint8
+										for i := 0; i < 10; i++ {
+											slog.Debug("reading u64 byte", "i", i)
+											b, err := r.ReadByte()
+											if err != nil {
+												if i > 0 && err == io.EOF {
+													err = io.ErrUnexpectedEOF
+												}
+												return x, fmt.Errorf("failed to read u64 byte: %w", err)
+											}
+											if s == 63 && b > 0x01 {
+												return x, errors.New("varint overflows a 64-bit integer")
+											}
+											if b < 0x80 {
+												return x | uint64(b)<<s, nil
+											}
+											x |= uint64(b&0x7f) << s
+											s += 7
+										}
+										return x, errors.New("varint overflows a 64-bit integer")
+									}(r)
+									if err != nil {
+										return nil, fmt.Errorf("failed to read `response-size` field: %w", err)
+									}
+									slog.Debug("reading field", "name", "duration-ns")
+									v.DurationNs, err = func(r io.ByteReader) (uint64, error) {
+										var x uint64
+										var s uint8
+										for i := 0; i < 10; i++ {
+											slog.Debug("reading u64 byte", "i", i)
+											b, err := r.ReadByte()
+											if err != nil {
+												if i > 0 && err == io.EOF {
+													err = io.ErrUnexpectedEOF
+												}
+												return x, fmt.Errorf("failed to read u64 byte: %w", err)
+											}
+											if s == 63 && b > 0x01 {
+												return x, errors.New("varint overflows a 64-bit integer")
+											}
+											if b < 0x80 {
+												return x | uint64(b)<<s, nil
+											}
+											x |= uint64(b&0x7f) << s
+											s += 7
+										}
+										return x, errors.New("varint overflows a 64-bit integer")
+									}(r)
+									if err != nil {
+										return nil, fmt.Errorf("failed to read `duration-ns` field: %w", err)
+									}
+									return v, nil
+								}(r, append(path, uint32(i))...)
+								if err != nil {
+									return nil, fmt.Errorf("failed to read list element %d: %w", i, err)
+								}
+							}
+							return vs, nil
+						}
+						x |= uint32(b&0x7f) << s
+						s += 7
+					}
+					return nil, errors.New("list length overflows a 32-bit integer")
+				}(r, append(path, 12)...)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `http-calls` field: %w", err)
+				}
+				return v, nil
+			}(r, path...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read `result::ok` value: %w", err)
+			}
+			return &wrpc.Result[Response, Error]{Ok: v}, nil
+		case 1:
+			slog.Debug("reading `result::err` payload")
+			v, err := func(r wrpc.IndexReadCloser, path ...uint32) (*Error, error) {
+				v := &Error{}
+				var err error
+				slog.Debug("reading field", "name", "id")
+				v.Id, err = func(r interface {
+					io.ByteReader
+					io.Reader
+				}) (string, error) {
+					var x uint32
+					var s uint8
+					for i := 0; i < 5; i++ {
+						slog.Debug("reading string length byte", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return "", fmt.Errorf("failed to read string length byte: %w", err)
+						}
+						if s == 28 && b > 0x0f {
+							return "", errors.New("string length overflows a 32-bit integer")
+						}
+						if b < 0x80 {
+							x = x | uint32(b)<<s
+							if x == 0 {
+								return "", nil
+							}
+							buf := make([]byte, x)
+							slog.Debug("reading string bytes", "len", x)
+							_, err = r.Read(buf)
+							if err != nil {
+								return "", fmt.Errorf("failed to read string bytes: %w", err)
+							}
+							if !utf8.Valid(buf) {
+								return string(buf), errors.New("string is not valid UTF-8")
+							}
+							return string(buf), nil
+						}
+						x |= uint32(b&0x7f) << s
+						s += 7
+					}
+					return "", errors.New("string length overflows a 32-bit integer")
+				}(r)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `id` field: %w", err)
+				}
+				slog.Debug("reading field", "name", "message")
+				v.Message, err = func(r interface {
+					io.ByteReader
+					io.Reader
+				}) (string, error) {
+					var x uint32
+					var s uint8
+					for i := 0; i < 5; i++ {
+						slog.Debug("reading string length byte", "i", i)
+						b, err := r.ReadByte()
+						if err != nil {
+							if i > 0 && err == io.EOF {
+								err = io.ErrUnexpectedEOF
+							}
+							return "", fmt.Errorf("failed to read string length byte: %w", err)
+						}
+						if s == 28 && b > 0x0f {
+							return "", errors.New("string length overflows a 32-bit integer")
+						}
+						if b < 0x80 {
+							x = x | uint32(b)<<s
+							if x == 0 {
+								return "", nil
+							}
+							buf := make([]byte, x)
+							slog.Debug("reading string bytes", "len", x)
+							_, err = r.Read(buf)
+							if err != nil {
+								return "", fmt.Errorf("failed to read string bytes: %w", err)
+							}
+							if !utf8.Valid(buf) {
+								return string(buf), errors.New("string is not valid UTF-8")
+							}
+							return string(buf), nil
+						}
+						x |= uint32(b&0x7f) << s
+						s += 7
+					}
+					return "", errors.New("string length overflows a 32-bit integer")
+				}(r)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read `message` field: %w", err)
+				}
+				return v, nil
+			}(r, path...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read `result::err` value: %w", err)
+			}
+			return &wrpc.Result[Response, Error]{Err: v}, nil
+		default:
+			return nil, fmt.Errorf("invalid result status byte %d", status)
+		}
+	}(r__, []uint32{0}...)
+	if err__ != nil {
+		err__ = fmt.Errorf("failed to read result 0: %w", err__)
+		return
 	}
 	return
 }
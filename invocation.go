@@ -0,0 +1,205 @@
+package k6wrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"go.k6.io/k6/js/modules"
+	"go.k6.io/k6/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	wrpc "wrpc.io/go"
+)
+
+// invocationInvoker wraps the invoker of a client, recording the `wrpc_invocation*` metrics for every
+// function invoked through it, tagged with the WIT `instance` and the `func` name. Each invocation is
+// a client span, whose trace context is sent in the transport headers.
+type invocationInvoker struct {
+	invoker wrpc.Invoker
+	vu      modules.VU
+	metrics *wrpcMetrics
+	tags    map[string]string
+	tracer  trace.Tracer
+	debug   *debugger
+}
+
+var _ wrpc.Invoker = (*invocationInvoker)(nil)
+
+func newInvocationInvoker(
+	vu modules.VU,
+	wm *wrpcMetrics,
+	invoker wrpc.Invoker,
+	tags map[string]string,
+	tracer trace.Tracer,
+	debug *debugger,
+) *invocationInvoker {
+	return &invocationInvoker{
+		invoker: invoker,
+		vu:      vu,
+		metrics: wm,
+		tags:    tags,
+		tracer:  tracer,
+		debug:   debug,
+	}
+}
+
+// invocationScope is where the invocation metrics of a call are pushed, and how they are tagged.
+type invocationScope struct {
+	ctx     context.Context
+	samples chan<- metrics.SampleContainer
+	tagSet  *metrics.TagSet
+}
+
+type invocationScopeKey struct{}
+
+// withInvocationScope sets the scope of the invocations made with ctx. Calls made off the VU goroutine
+// must set it, others default to the VU's tags and the client's tags.
+func withInvocationScope(ctx context.Context, scope invocationScope) context.Context {
+	return context.WithValue(ctx, invocationScopeKey{}, scope)
+}
+
+func (i *invocationInvoker) scope(ctx context.Context) (invocationScope, bool) {
+	if scope, ok := ctx.Value(invocationScopeKey{}).(invocationScope); ok {
+		return scope, true
+	}
+	state := i.vu.State()
+	if state == nil {
+		return invocationScope{}, false
+	}
+	return invocationScope{
+		ctx:     i.vu.Context(),
+		samples: state.Samples,
+		tagSet:  state.Tags.GetCurrentValues().Tags.WithTagsFromMap(i.tags),
+	}, true
+}
+
+func (i *invocationInvoker) Invoke(
+	ctx context.Context,
+	instance string,
+	name string,
+	params []byte,
+	paths ...wrpc.SubscribePath,
+) (wrpc.IndexWriteCloser, wrpc.IndexReadCloser, error) {
+	scope, ok := i.scope(ctx)
+	if !ok {
+		return i.invoker.Invoke(ctx, instance, name, params, paths...)
+	}
+
+	ctx, span := i.tracer.Start(ctx, instance+"/"+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "wrpc"),
+			attribute.String("rpc.service", instance),
+			attribute.String("rpc.method", name),
+		),
+	)
+	ctx = withTraceContextHeader(ctx)
+
+	inv := &invocation{
+		metrics:  i.metrics,
+		debug:    i.debug,
+		scope:    scope,
+		tagSet:   scope.tagSet.With("interface", instance).With("func", name),
+		span:     span,
+		instance: instance,
+		name:     name,
+		params:   params,
+		start:    time.Now(),
+	}
+	w, r, err := i.invoker.Invoke(ctx, instance, name, params, paths...)
+	if err != nil {
+		inv.done(err, 0)
+		return nil, nil, err
+	}
+	return w, &invocationReader{IndexReadCloser: r, inv: inv}, nil
+}
+
+// invocation lasts until the bindings close its reader, once they've read its results.
+// Streams and futures nested in the results can outlive it.
+type invocation struct {
+	metrics  *wrpcMetrics
+	debug    *debugger
+	scope    invocationScope
+	tagSet   *metrics.TagSet
+	span     trace.Span
+	instance string
+	name     string
+	params   []byte
+	start    time.Time
+	once     sync.Once
+}
+
+// done records the invocation, results is the size of its encoded results.
+func (inv *invocation) done(err error, results int64) {
+	inv.once.Do(func() {
+		inv.debug.dumpInvocation(inv.instance, inv.name, inv.params, results, time.Since(inv.start), err)
+
+		samples := []metrics.Sample{
+			inv.metrics.sample(inv.metrics.invocations, 1, inv.tagSet),
+			inv.metrics.sample(inv.metrics.invocationDuration, metrics.D(time.Since(inv.start)), inv.tagSet),
+		}
+		if err != nil {
+			samples = append(samples, inv.metrics.sample(inv.metrics.invocationErrors, 1, inv.tagSet))
+			inv.span.RecordError(err)
+			inv.span.SetStatus(codes.Error, err.Error())
+		}
+		inv.span.End()
+		metrics.PushIfNotDone(inv.scope.ctx, inv.scope.samples, metrics.Samples(samples))
+	})
+}
+
+// invocationReader keeps the first error reading the results, so the invocation can be counted as failed.
+type invocationReader struct {
+	wrpc.IndexReadCloser
+	inv  *invocation
+	err  error
+	read int64
+}
+
+func (r *invocationReader) check(err error) {
+	if err != nil && r.err == nil && !errors.Is(err, io.EOF) {
+		r.err = err
+	}
+}
+
+func (r *invocationReader) Read(b []byte) (int, error) {
+	n, err := r.IndexReadCloser.Read(b)
+	r.read += int64(n)
+	r.check(err)
+	return n, err
+}
+
+func (r *invocationReader) ReadByte() (byte, error) {
+	b, err := r.IndexReadCloser.ReadByte()
+	if err == nil {
+		r.read++
+	}
+	r.check(err)
+	return b, err
+}
+
+func (r *invocationReader) Close() error {
+	err := r.IndexReadCloser.Close()
+	r.check(err)
+	r.inv.done(r.err, r.read)
+	return err
+}
+
+// drainWriteErrs waits for the deferred writes of an invocation, such as streamed params, returning the first
+// error. writeErrs is nil when the params were all written along with the invocation.
+func drainWriteErrs(writeErrs <-chan error) error {
+	if writeErrs == nil {
+		return nil
+	}
+	var first error
+	for err := range writeErrs {
+		if first == nil {
+			first = err
+		}
+	}
+	return first
+}
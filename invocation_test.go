@@ -0,0 +1,117 @@
+package k6wrpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.k6.io/k6/metrics"
+	"go.opentelemetry.io/otel/trace/noop"
+	wrpc "wrpc.io/go"
+)
+
+// testReader is a top-level invocation reader over fixed results, failing with err once they are read.
+type testReader struct {
+	*bytes.Reader
+	err error
+}
+
+func (r *testReader) Read(b []byte) (int, error) {
+	n, err := r.Reader.Read(b)
+	if err == io.EOF && r.err != nil {
+		err = r.err
+	}
+	return n, err
+}
+
+func (r *testReader) Index(...uint32) (wrpc.IndexReadCloser, error) {
+	return nil, errors.New("no nested readers")
+}
+
+func (r *testReader) Close() error {
+	return nil
+}
+
+type testInvoker struct {
+	readErr   error
+	invokeErr error
+}
+
+func (i *testInvoker) Invoke(context.Context, string, string, []byte, ...wrpc.SubscribePath) (wrpc.IndexWriteCloser, wrpc.IndexReadCloser, error) {
+	if i.invokeErr != nil {
+		return nil, nil, i.invokeErr
+	}
+	return nil, &testReader{Reader: bytes.NewReader([]byte("ok")), err: i.readErr}, nil
+}
+
+func TestInvocationInvoker(t *testing.T) {
+	t.Parallel()
+
+	testdata := map[string]struct {
+		invoker *testInvoker
+		errors  float64
+	}{
+		"ok":            {&testInvoker{}, 0},
+		"invoke failed": {&testInvoker{invokeErr: errors.New("no responders")}, 1},
+		"read failed":   {&testInvoker{readErr: errors.New("connection reset")}, 1},
+	}
+	for name, data := range testdata {
+		data := data
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			registry := metrics.NewRegistry()
+			wm := newWrpcMetrics(registry)
+			samples := make(chan metrics.SampleContainer, 1)
+			scope := invocationScope{
+				ctx:     context.Background(),
+				samples: samples,
+				tagSet:  registry.RootTagSet().With("scenario", "test"),
+			}
+
+			invoker := newInvocationInvoker(nil, wm, data.invoker, nil, noop.NewTracerProvider().Tracer(tracerName), nil)
+			ctx := withInvocationScope(context.Background(), scope)
+			_, r, err := invoker.Invoke(ctx, "xk6:wrpc/blaster@0.0.1", "blast", nil)
+			if err == nil {
+				_, _ = io.ReadAll(r)
+				require.NoError(t, r.Close())
+				// closing twice doesn't count the invocation twice
+				require.NoError(t, r.Close())
+			}
+
+			require.Len(t, samples, 1)
+			totals := make(map[string]float64)
+			for _, sample := range (<-samples).GetSamples() {
+				totals[sample.Metric.Name] += sample.Value
+				assert.Equal(t, map[string]string{
+					"scenario":  "test",
+					"interface": "xk6:wrpc/blaster@0.0.1",
+					"func":      "blast",
+				}, sample.Tags.Map())
+			}
+			assert.Equal(t, 1.0, totals[metricInvocations])
+			assert.Contains(t, totals, metricInvocationDuration)
+			assert.Equal(t, data.errors, totals[metricInvocationErrors])
+		})
+	}
+}
+
+func TestDrainWriteErrs(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, drainWriteErrs(nil))
+
+	writeErrs := make(chan error, 2)
+	close(writeErrs)
+	assert.NoError(t, drainWriteErrs(writeErrs))
+
+	writeErrs = make(chan error, 2)
+	writeErrs <- errors.New("body truncated")
+	writeErrs <- errors.New("trailers not sent")
+	close(writeErrs)
+	assert.EqualError(t, drainWriteErrs(writeErrs), "body truncated")
+}
@@ -20,13 +20,42 @@ type wrpcMetrics struct {
 	httpError *metrics.Metric
 	// http request x response duration
 	httpDuration *metrics.Metric
+	// request body size, as sent on the wire
+	httpRequestSize *metrics.Metric
+	// response body size, as received on the wire
+	httpResponseWireSize *metrics.Metric
+	// response body size, after decompression
+	httpResponseSize *metrics.Metric
 
 	// operations
 	blasterOperation *metrics.Metric
 	// underlying wrpc encoding errors
 	blasterTransportError *metrics.Metric
+	// errors returned by the component
+	blasterError *metrics.Metric
 	// operation duration
 	blasterDuration *metrics.Metric
+	// time spent in the component, as reported by the server
+	blasterServerDuration *metrics.Metric
+	// operation duration minus server duration
+	blasterOverhead *metrics.Metric
+	// blasts not sent by flood because too many were in flight
+	blasterDropped *metrics.Metric
+	// client to server latency, corrected for the estimated server clock offset
+	blasterRequestLatency *metrics.Metric
+	// server to client latency, corrected for the estimated server clock offset
+	blasterResponseLatency *metrics.Metric
+	// payloads received by the component that don't match the ones sent
+	blasterCorrupt *metrics.Metric
+
+	// functions invoked, by any client
+	invocations *metrics.Metric
+	// time until the results of an invocation were read
+	invocationDuration *metrics.Metric
+	// invocations that failed to be sent or to get results
+	invocationErrors *metrics.Metric
+	// invocations whose streamed params, such as request bodies, failed to be written
+	writeError *metrics.Metric
 }
 
 const (
@@ -36,24 +65,54 @@ const (
 	metricHTTPError           = "wrpc_http_error"
 	metricTransportError      = "wrpc_transport_error"
 	metricHTTPDuration        = "wrpc_http_duration"
+	metricHTTPRequestSize     = "wrpc_http_request_size"
+	metricHTTPResponseWire    = "wrpc_http_response_wire_size"
+	metricHTTPResponseSize    = "wrpc_http_response_size"
+
+	metriBlasterOperation        = "wrpc_blaster_operation"
+	metricBlasterTransportError  = "wrpc_blaster_transport_error"
+	metricBlasterError           = "wrpc_blaster_error"
+	metricBlasterDuration        = "wrpc_blaster_duration"
+	metricBlasterServerDuration  = "wrpc_blaster_server_duration"
+	metricBlasterOverhead        = "wrpc_blaster_overhead"
+	metricBlasterDropped         = "wrpc_blaster_dropped"
+	metricBlasterRequestLatency  = "wrpc_blaster_request_latency"
+	metricBlasterResponseLatency = "wrpc_blaster_response_latency"
+	metricBlasterCorrupt         = "wrpc_blaster_corrupt"
 
-	metriBlasterOperation       = "wrpc_blaster_operation"
-	metricBlasterTransportError = "wrpc_blaster_transport_error"
-	metricBlasterDuration       = "wrpc_blaster_duration"
+	metricInvocations        = "wrpc_invocations"
+	metricInvocationDuration = "wrpc_invocation_duration"
+	metricInvocationErrors   = "wrpc_invocation_errors"
+	metricWriteError         = "wrpc_write_error"
 )
 
 func newWrpcMetrics(registry *metrics.Registry) *wrpcMetrics {
 	return &wrpcMetrics{
-		httpRequest:         registry.MustNewMetric(metricHTTPRequest, metrics.Counter),
-		httpResponse:        registry.MustNewMetric(metricHTTPResponse, metrics.Counter),
-		httpDuration:        registry.MustNewMetric(metricHTTPDuration, metrics.Trend, metrics.Time),
-		httpInvalidResponse: registry.MustNewMetric(metricHTTPInvalidResponse, metrics.Counter),
-		httpError:           registry.MustNewMetric(metricHTTPError, metrics.Counter),
-		transportError:      registry.MustNewMetric(metricTransportError, metrics.Counter),
+		httpRequest:          registry.MustNewMetric(metricHTTPRequest, metrics.Counter),
+		httpResponse:         registry.MustNewMetric(metricHTTPResponse, metrics.Counter),
+		httpDuration:         registry.MustNewMetric(metricHTTPDuration, metrics.Trend, metrics.Time),
+		httpInvalidResponse:  registry.MustNewMetric(metricHTTPInvalidResponse, metrics.Counter),
+		httpError:            registry.MustNewMetric(metricHTTPError, metrics.Counter),
+		transportError:       registry.MustNewMetric(metricTransportError, metrics.Counter),
+		httpRequestSize:      registry.MustNewMetric(metricHTTPRequestSize, metrics.Trend, metrics.Data),
+		httpResponseWireSize: registry.MustNewMetric(metricHTTPResponseWire, metrics.Trend, metrics.Data),
+		httpResponseSize:     registry.MustNewMetric(metricHTTPResponseSize, metrics.Trend, metrics.Data),
+
+		blasterOperation:       registry.MustNewMetric(metriBlasterOperation, metrics.Counter),
+		blasterTransportError:  registry.MustNewMetric(metricBlasterTransportError, metrics.Counter),
+		blasterError:           registry.MustNewMetric(metricBlasterError, metrics.Counter),
+		blasterDuration:        registry.MustNewMetric(metricBlasterDuration, metrics.Trend, metrics.Time),
+		blasterServerDuration:  registry.MustNewMetric(metricBlasterServerDuration, metrics.Trend, metrics.Time),
+		blasterOverhead:        registry.MustNewMetric(metricBlasterOverhead, metrics.Trend, metrics.Time),
+		blasterDropped:         registry.MustNewMetric(metricBlasterDropped, metrics.Counter),
+		blasterRequestLatency:  registry.MustNewMetric(metricBlasterRequestLatency, metrics.Trend, metrics.Time),
+		blasterResponseLatency: registry.MustNewMetric(metricBlasterResponseLatency, metrics.Trend, metrics.Time),
+		blasterCorrupt:         registry.MustNewMetric(metricBlasterCorrupt, metrics.Counter),
 
-		blasterOperation:      registry.MustNewMetric(metriBlasterOperation, metrics.Counter),
-		blasterTransportError: registry.MustNewMetric(metricBlasterTransportError, metrics.Counter),
-		blasterDuration:       registry.MustNewMetric(metricBlasterDuration, metrics.Trend, metrics.Time),
+		invocations:        registry.MustNewMetric(metricInvocations, metrics.Counter),
+		invocationDuration: registry.MustNewMetric(metricInvocationDuration, metrics.Trend, metrics.Time),
+		invocationErrors:   registry.MustNewMetric(metricInvocationErrors, metrics.Counter),
+		writeError:         registry.MustNewMetric(metricWriteError, metrics.Counter),
 	}
 }
 
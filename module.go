@@ -10,6 +10,9 @@ import (
 	"go.k6.io/k6/js/common"
 	"go.k6.io/k6/js/modules"
 	"go.k6.io/k6/lib/netext"
+	"go.k6.io/k6/lib/netext/httpext"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // RootModule is the global module object type. It is instantiated once per test
@@ -17,7 +20,16 @@ import (
 //
 // TODO: add sync.Once for all of the deprecation warnings we might want to do
 // for the old k6/http APIs here, so they are shown only once in a test run.
-type RootModule struct{}
+type RootModule struct {
+	payloadPoolsMu sync.Mutex
+	payloadPools   map[string]*payloadPool
+
+	tracerProvidersMu sync.Mutex
+	tracerProviders   map[string]*sdktrace.TracerProvider
+
+	debugLimiterOnce sync.Once
+	debugLimiter     *debugLimiter
+}
 
 // ModuleInstance represents an instance of the WRPC module for every VU.
 type ModuleInstance struct {
@@ -69,6 +81,9 @@ func (r *RootModule) NewModuleInstance(vu modules.VU) modules.Instance {
 
 	mustExport("http", mi.httpClient)
 	mustExport("blaster", mi.blasterClient)
+	mustExport("file", mi.file)
+	mustExport("url", taggedURL)
+	mustExport("payloadPool", mi.payloadPool)
 
 	return mi
 }
@@ -76,6 +91,14 @@ func (r *RootModule) NewModuleInstance(vu modules.VU) modules.Instance {
 type clientOptions struct {
 	Tags map[string]string `json:"tags,omitempty"`
 	NATS *natsClientOption `json:"nats,omitempty"`
+	// max redirects followed by http clients
+	Redirects *int64          `json:"redirects,omitempty"`
+	Tracing   *tracingOptions `json:"tracing,omitempty"`
+	// "headers" or "full"
+	Debug string `json:"debug,omitempty"`
+
+	tracer   trace.Tracer
+	debugger *debugger
 }
 
 func (mi *ModuleInstance) blasterClient(rawOptions *sobek.Object) *sobek.Object {
@@ -94,6 +117,14 @@ func (mi *ModuleInstance) blasterClient(rawOptions *sobek.Object) *sobek.Object
 		common.Throw(rt, err)
 		return nil
 	}
+	if options.tracer, err = mi.tracer(options.Tracing); err != nil {
+		common.Throw(rt, err)
+		return nil
+	}
+	if options.debugger, err = mi.debugger(options.Debug); err != nil {
+		common.Throw(rt, err)
+		return nil
+	}
 
 	w, err := newBlaster(mi.vu, mi.metrics, options)
 	if err != nil {
@@ -120,6 +151,14 @@ func (mi *ModuleInstance) httpClient(rawOptions *sobek.Object) *sobek.Object {
 		common.Throw(rt, err)
 		return nil
 	}
+	if options.tracer, err = mi.tracer(options.Tracing); err != nil {
+		common.Throw(rt, err)
+		return nil
+	}
+	if options.debugger, err = mi.debugger(options.Debug); err != nil {
+		common.Throw(rt, err)
+		return nil
+	}
 
 	w, err := newWasiHTTP(mi.vu, mi.metrics, options)
 	if err != nil {
@@ -130,6 +169,21 @@ func (mi *ModuleInstance) httpClient(rawOptions *sobek.Object) *sobek.Object {
 	return w.obj
 }
 
+// taggedURL is the `http.url` tagged template helper, so dynamic urls are grouped
+// under a single `name` tag.
+func taggedURL(parts []string, pieces ...string) (httpext.URL, error) {
+	var name, urlstr string
+	for i, part := range parts {
+		name += part
+		urlstr += part
+		if i < len(pieces) {
+			name += "${}"
+			urlstr += pieces[i]
+		}
+	}
+	return httpext.NewURL(urlstr, name)
+}
+
 // Exports returns the JS values this module exports.
 func (mi *ModuleInstance) Exports() modules.Exports {
 	return modules.Exports{
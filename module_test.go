@@ -7,7 +7,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.k6.io/k6/js/modulestest"
+	"go.k6.io/k6/lib"
 	"go.k6.io/k6/lib/netext/httpext"
+	"go.k6.io/k6/metrics"
 )
 
 func getTestModuleInstance(t testing.TB) (*modulestest.Runtime, *ModuleInstance) {
@@ -20,6 +22,27 @@ func getTestModuleInstance(t testing.TB) (*modulestest.Runtime, *ModuleInstance)
 	return runtime, mi
 }
 
+// getTestVU moves a test runtime to the VU context of VU vuID, with the default system tags,
+// and returns the channel buffering its samples.
+func getTestVU(t testing.TB, vuID uint64) (*modulestest.Runtime, *wrpcMetrics, chan metrics.SampleContainer) {
+	runtime, _ := getTestModuleInstance(t)
+	registry := metrics.NewRegistry()
+	samples := make(chan metrics.SampleContainer, 100)
+	runtime.MoveToVUContext(&lib.State{
+		VUID:    vuID,
+		Options: lib.Options{SystemTags: &metrics.DefaultSystemTagSet},
+		Samples: samples,
+		Tags:    lib.NewVUStateTags(registry.RootTagSet()),
+	})
+	return runtime, newWrpcMetrics(registry), samples
+}
+
+// getTestBlaster returns a blaster running in the VU context of VU vuID.
+func getTestBlaster(t testing.TB, vuID uint64) (*modulestest.Runtime, *wasiBlaster) {
+	runtime, wm, _ := getTestVU(t, vuID)
+	return runtime, &wasiBlaster{vu: runtime.VU, metrics: wm, rands: make(map[uint64]*packetRand)}
+}
+
 func TestTagURL(t *testing.T) {
 	t.Parallel()
 
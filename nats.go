@@ -3,6 +3,7 @@ package k6wrpc
 import (
 	"github.com/nats-io/nats.go"
 	"go.k6.io/k6/js/modules"
+	wrpc "wrpc.io/go"
 	wrpcnats "wrpc.io/go/nats"
 )
 
@@ -14,16 +15,19 @@ type natsClientOption struct {
 type natsDriver struct {
 	nc   *wrpcnats.Client
 	tags map[string]string
+	// nc, recording the invocation metrics
+	invoker wrpc.Invoker
 }
 
-func newNatsDriver(vu modules.VU, wm *wrpcMetrics, options *natsClientOption, tags map[string]string) (*natsDriver, error) {
-	nc, err := nats.Connect(options.URL)
+func newNatsDriver(vu modules.VU, wm *wrpcMetrics, options clientOptions) (*natsDriver, error) {
+	nc, err := nats.Connect(options.NATS.URL)
 	if err != nil {
 		return nil, err
 	}
 	client := &natsDriver{
-		nc:   wrpcnats.NewClient(nc, wrpcnats.WithPrefix(options.Prefix)),
-		tags: tags,
+		nc:   wrpcnats.NewClient(nc, wrpcnats.WithPrefix(options.NATS.Prefix)),
+		tags: options.Tags,
 	}
+	client.invoker = newInvocationInvoker(vu, wm, client.nc, options.Tags, options.tracer, options.debugger)
 	return client, nil
 }
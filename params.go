@@ -0,0 +1,391 @@
+package k6wrpc
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/lib/types"
+)
+
+// paramsError is returned when the params passed to a client call fail validation.
+// It is thrown to JS as an error whose value exposes the offending path.
+type paramsError struct {
+	Name    string `js:"name"`
+	Path    string `js:"path"`
+	Message string `js:"message"`
+}
+
+var _ error = (*paramsError)(nil)
+
+func (e *paramsError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.Name, e.Path, e.Message)
+}
+
+func newParamsError(path string, format string, args ...interface{}) *paramsError {
+	return &paramsError{
+		Name:    "ParamsError",
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// paramsDecoder decodes a JS params object, keeping track of the keys that were
+// looked up so unknown keys can be reported. Only the first error is kept.
+type paramsDecoder struct {
+	rt       *sobek.Runtime
+	path     string
+	obj      *sobek.Object
+	values   map[string]interface{}
+	known    map[string]bool
+	children []*paramsDecoder
+	err      error
+}
+
+func newParamsDecoder(rt *sobek.Runtime, path string, v sobek.Value) *paramsDecoder {
+	d := &paramsDecoder{
+		rt:     rt,
+		path:   path,
+		values: make(map[string]interface{}),
+		known:  make(map[string]bool),
+	}
+	if v == nil || sobek.IsUndefined(v) || sobek.IsNull(v) {
+		return d
+	}
+
+	obj, ok := v.(*sobek.Object)
+	if !ok {
+		d.err = newParamsError(path, "expected an object, got %s", v.ExportType())
+		return d
+	}
+	values, ok := obj.Export().(map[string]interface{})
+	if !ok {
+		d.err = newParamsError(path, "expected an object, got %T", obj.Export())
+		return d
+	}
+
+	d.obj = obj
+	d.values = values
+	return d
+}
+
+func (d *paramsDecoder) keyPath(key string) string {
+	return d.path + "." + key
+}
+
+func (d *paramsDecoder) fail(key string, format string, args ...interface{}) {
+	if d.err == nil {
+		d.err = newParamsError(d.keyPath(key), format, args...)
+	}
+}
+
+// lookup returns the exported value for key, treating null and undefined as unset.
+func (d *paramsDecoder) lookup(key string) (interface{}, bool) {
+	d.known[key] = true
+	v, ok := d.values[key]
+	if !ok || v == nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// Check records err, if any, as the error for key.
+func (d *paramsDecoder) Check(key string, err error) {
+	if err != nil {
+		d.fail(key, "%s", err)
+	}
+}
+
+// Unsupported accepts key so it isn't reported as unknown, but fails if it's set.
+func (d *paramsDecoder) Unsupported(key string) {
+	if _, ok := d.lookup(key); ok {
+		d.fail(key, "not supported")
+	}
+}
+
+// Value returns the raw JS value for key, or nil if unset.
+func (d *paramsDecoder) Value(key string) sobek.Value {
+	if _, ok := d.lookup(key); !ok {
+		return nil
+	}
+	return d.obj.Get(key)
+}
+
+// Object returns a decoder for the nested object at key, or nil if unset.
+func (d *paramsDecoder) Object(key string) *paramsDecoder {
+	v := d.Value(key)
+	if v == nil {
+		return nil
+	}
+	child := newParamsDecoder(d.rt, d.keyPath(key), v)
+	d.children = append(d.children, child)
+	return child
+}
+
+// Map decodes a plain object at key.
+func (d *paramsDecoder) Map(key string, dst *map[string]interface{}) {
+	v, ok := d.lookup(key)
+	if !ok {
+		return
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		d.fail(key, "expected an object, got %T", v)
+		return
+	}
+	*dst = m
+}
+
+// StringMap decodes an object of strings at key, such as tags, into dst.
+func (d *paramsDecoder) StringMap(key string, dst map[string]string) {
+	v, ok := d.lookup(key)
+	if !ok {
+		return
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		d.fail(key, "expected an object, got %T", v)
+		return
+	}
+	for k, mv := range m {
+		s, ok := mv.(string)
+		if !ok {
+			d.fail(key+"."+k, "expected a string, got %T", mv)
+			return
+		}
+		dst[k] = s
+	}
+}
+
+func (d *paramsDecoder) String(key string, dst *string) {
+	v, ok := d.lookup(key)
+	if !ok {
+		return
+	}
+	s, ok := v.(string)
+	if !ok {
+		d.fail(key, "expected a string, got %T", v)
+		return
+	}
+	*dst = s
+}
+
+// Bytes accepts strings, ArrayBuffers and typed arrays, such as the result of open(file, "b").
+func (d *paramsDecoder) Bytes(key string, dst *[]byte) {
+	v, ok := d.lookup(key)
+	if !ok {
+		return
+	}
+	b, err := payloadBytes(v)
+	if err != nil {
+		d.fail(key, "%s", err)
+		return
+	}
+	*dst = b
+}
+
+func (d *paramsDecoder) Bool(key string, dst *bool) {
+	v, ok := d.lookup(key)
+	if !ok {
+		return
+	}
+	b, ok := v.(bool)
+	if !ok {
+		d.fail(key, "expected a boolean, got %T", v)
+		return
+	}
+	*dst = b
+}
+
+func (d *paramsDecoder) Int(key string, dst *int64) {
+	v, ok := d.lookup(key)
+	if !ok {
+		return
+	}
+	switch n := v.(type) {
+	case int64:
+		*dst = n
+	case float64:
+		if n != math.Trunc(n) || math.IsInf(n, 0) {
+			d.fail(key, "expected an integer, got %v", n)
+			return
+		}
+		*dst = int64(n)
+	default:
+		d.fail(key, "expected an integer, got %T", v)
+	}
+}
+
+func (d *paramsDecoder) Uint(key string, dst *uint64) {
+	var n int64
+	if _, ok := d.lookup(key); !ok {
+		return
+	}
+	d.Int(key, &n)
+	if d.err != nil {
+		return
+	}
+	if n < 0 {
+		d.fail(key, "expected a non-negative integer, got %d", n)
+		return
+	}
+	*dst = uint64(n)
+}
+
+func (d *paramsDecoder) Uint32(key string, dst *uint32) {
+	var n uint64
+	if _, ok := d.lookup(key); !ok {
+		return
+	}
+	d.Uint(key, &n)
+	if d.err != nil {
+		return
+	}
+	if n > math.MaxUint32 {
+		d.fail(key, "expected an integer no larger than %d, got %d", uint64(math.MaxUint32), n)
+		return
+	}
+	*dst = uint32(n)
+}
+
+func (d *paramsDecoder) Float(key string, dst *float64) {
+	v, ok := d.lookup(key)
+	if !ok {
+		return
+	}
+	switch n := v.(type) {
+	case int64:
+		*dst = float64(n)
+	case float64:
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			d.fail(key, "expected a finite number, got %v", n)
+			return
+		}
+		*dst = n
+	default:
+		d.fail(key, "expected a number, got %T", v)
+	}
+}
+
+// Duration accepts k6 duration strings ("5s", "1m30s") and numbers in milliseconds.
+func (d *paramsDecoder) Duration(key string, dst *time.Duration) {
+	v, ok := d.lookup(key)
+	if !ok {
+		return
+	}
+	switch v.(type) {
+	case string, int64, float64:
+	default:
+		d.fail(key, "expected a duration string or a number of milliseconds, got %T", v)
+		return
+	}
+	duration, err := types.GetDurationValue(v)
+	if err != nil {
+		d.fail(key, "invalid duration: %s", err)
+		return
+	}
+	if duration < 0 {
+		d.fail(key, "expected a positive duration, got %s", duration)
+		return
+	}
+	*dst = duration
+}
+
+// Distribution accepts either a fixed non-negative number or a distribution spec such as
+// `{ dist: "normal", mean: 100, stddev: 20 }`.
+func (d *paramsDecoder) Distribution(key string, dst **distribution) {
+	v, ok := d.lookup(key)
+	if !ok {
+		return
+	}
+	switch v.(type) {
+	case int64, float64:
+		dist := &distribution{kind: distFixed}
+		d.Float(key, &dist.value)
+		if dist.value < 0 {
+			d.fail(key, "expected a non-negative number, got %v", dist.value)
+			return
+		}
+		*dst = dist
+		return
+	case map[string]interface{}:
+	default:
+		d.fail(key, "expected a number or a distribution, got %T", v)
+		return
+	}
+
+	dist := &distribution{}
+	c := d.Object(key)
+	c.String("dist", &dist.kind)
+	c.Float("max", &dist.max)
+	switch dist.kind {
+	case distUniform:
+		c.Float("min", &dist.min)
+		if _, ok := c.lookup("max"); !ok {
+			c.fail("max", "required by the uniform distribution")
+		}
+		if dist.min < 0 || dist.max < dist.min {
+			c.fail("max", "expected 0 <= min <= max, got min %v and max %v", dist.min, dist.max)
+		}
+	case distNormal:
+		c.Float("mean", &dist.mean)
+		c.Float("stddev", &dist.stddev)
+		if dist.stddev < 0 {
+			c.fail("stddev", "expected a non-negative standard deviation, got %v", dist.stddev)
+		}
+	case distExponential:
+		c.Float("mean", &dist.mean)
+		if dist.mean <= 0 {
+			c.fail("mean", "expected a positive mean, got %v", dist.mean)
+		}
+	case distPareto:
+		c.Float("scale", &dist.scale)
+		c.Float("shape", &dist.shape)
+		if dist.scale <= 0 {
+			c.fail("scale", "expected a positive scale, got %v", dist.scale)
+		}
+		if dist.shape <= 0 {
+			c.fail("shape", "expected a positive shape, got %v", dist.shape)
+		}
+	default:
+		c.fail("dist", "expected one of %q, %q, %q or %q, got %q", distUniform, distNormal, distExponential, distPareto, dist.kind)
+	}
+	if dist.max < 0 {
+		c.fail("max", "expected a non-negative number, got %v", dist.max)
+	}
+	*dst = dist
+}
+
+// Err returns the first decoding error, or reports keys that were never looked up.
+func (d *paramsDecoder) Err() error {
+	if d.err != nil {
+		return d.err
+	}
+	for _, child := range d.children {
+		if err := child.Err(); err != nil {
+			return err
+		}
+	}
+
+	var unknown []string
+	for key := range d.values {
+		if !d.known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	slices.Sort(unknown)
+
+	known := make([]string, 0, len(d.known))
+	for key := range d.known {
+		known = append(known, key)
+	}
+	slices.Sort(known)
+
+	return newParamsError(d.keyPath(unknown[0]), "unknown key, expected one of: %s", strings.Join(known, ", "))
+}
@@ -0,0 +1,124 @@
+package k6wrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamsDecoder(t *testing.T) {
+	t.Parallel()
+
+	runtime, _ := getTestModuleInstance(t)
+	rt := runtime.VU.Runtime()
+	v, err := rt.RunString(`({
+		timeout: "1m30s",
+		consume: true,
+		redirects: 3,
+		ratio: 0.25,
+		auth: { username: "user", password: "pass" },
+		tags: { name: "x" },
+		unset: undefined,
+	})`)
+	require.NoError(t, err)
+
+	d := newParamsDecoder(rt, "params", v)
+	var (
+		timeout   time.Duration
+		consume   bool
+		redirects int64
+		ratio     float64
+		user      string
+		unset     string
+	)
+	tags := make(map[string]string)
+	d.Duration("timeout", &timeout)
+	d.Bool("consume", &consume)
+	d.Int("redirects", &redirects)
+	d.Float("ratio", &ratio)
+	d.Object("auth").String("username", &user)
+	d.StringMap("tags", tags)
+	d.String("unset", &unset)
+
+	err = d.Err()
+	require.Error(t, err)
+	assert.Equal(t, "params.auth.password", err.(*paramsError).Path)
+
+	assert.Equal(t, 90*time.Second, timeout)
+	assert.True(t, consume)
+	assert.Equal(t, int64(3), redirects)
+	assert.Equal(t, 0.25, ratio)
+	assert.Equal(t, "user", user)
+	assert.Equal(t, map[string]string{"name": "x"}, tags)
+	assert.Empty(t, unset)
+}
+
+func TestParamsDecoderErrors(t *testing.T) {
+	t.Parallel()
+
+	testdata := map[string]struct {
+		expr, path, msg string
+	}{
+		"duration string": {`({timeout: "5 seconds"})`, "params.timeout", "invalid duration"},
+		"duration type":   {`({timeout: true})`, "params.timeout", "expected a duration string or a number of milliseconds"},
+		"float int":       {`({redirects: 1.5})`, "params.redirects", "expected an integer, got 1.5"},
+		"negative uint":   {`({wait_ms: -1})`, "params.wait_ms", "expected a non-negative integer"},
+		"uint32 overflow": {`({hops: 4294967296})`, "params.hops", "expected an integer no larger than 4294967295"},
+		"float":           {`({ratio: "half"})`, "params.ratio", "expected a number, got string"},
+		"dist type":       {`({cpu_burn_ms: "10"})`, "params.cpu_burn_ms", "expected a number or a distribution"},
+		"dist negative":   {`({cpu_burn_ms: -10})`, "params.cpu_burn_ms", "expected a non-negative number"},
+		"dist kind":       {`({cpu_burn_ms: {dist: "zipf"}})`, "params.cpu_burn_ms.dist", `expected one of "uniform"`},
+		"dist bounds":     {`({cpu_burn_ms: {dist: "uniform", min: 10, max: 5}})`, "params.cpu_burn_ms.max", "expected 0 <= min <= max"},
+		"dist param":      {`({cpu_burn_ms: {dist: "pareto", scale: 1, shape: 0}})`, "params.cpu_burn_ms.shape", "expected a positive shape"},
+		"dist unknown":    {`({cpu_burn_ms: {dist: "exponential", mean: 1, stddev: 1}})`, "params.cpu_burn_ms.stddev", "unknown key"},
+		"bytes":           {`({payload: 1})`, "params.payload", "expected a string, an ArrayBuffer or a Uint8Array"},
+		"bool":            {`({consume: "yes"})`, "params.consume", "expected a boolean, got string"},
+		"nested object":   {`({auth: "user:pass"})`, "params.auth", "expected an object"},
+		"tag value":       {`({tags: {a: 1}})`, "params.tags.a", "expected a string"},
+		"unknown key":     {`({consume: true, cookies: {}})`, "params.cookies", "unknown key, expected one of: auth, consume"},
+		"unsupported":     {`({jar: {}})`, "params.jar", "not supported"},
+		"not an object":   {`"params"`, "params", "expected an object"},
+	}
+	for name, data := range testdata {
+		data := data
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			runtime, _ := getTestModuleInstance(t)
+			rt := runtime.VU.Runtime()
+			v, err := rt.RunString(data.expr)
+			require.NoError(t, err)
+
+			var (
+				timeout   time.Duration
+				redirects int64
+				waitMs    uint64
+				hops      uint32
+				ratio     float64
+				consume   bool
+				cpuBurn   *distribution
+				payload   []byte
+			)
+			d := newParamsDecoder(rt, "params", v)
+			d.Duration("timeout", &timeout)
+			d.Int("redirects", &redirects)
+			d.Uint("wait_ms", &waitMs)
+			d.Uint32("hops", &hops)
+			d.Float("ratio", &ratio)
+			d.Bool("consume", &consume)
+			d.Distribution("cpu_burn_ms", &cpuBurn)
+			d.Bytes("payload", &payload)
+			d.Object("auth")
+			d.StringMap("tags", make(map[string]string))
+			d.Unsupported("jar")
+
+			err = d.Err()
+			require.Error(t, err)
+			var pErr *paramsError
+			require.ErrorAs(t, err, &pErr)
+			assert.Equal(t, data.path, pErr.Path)
+			assert.Contains(t, pErr.Message, data.msg)
+		})
+	}
+}
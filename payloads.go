@@ -0,0 +1,79 @@
+package k6wrpc
+
+import (
+	"fmt"
+
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/js/common"
+)
+
+// payloadPool holds binary payloads shared by every VU, blasts pick one of them at random.
+type payloadPool struct {
+	Name string `js:"name"`
+	Size int    `js:"size"`
+
+	payloads [][]byte
+}
+
+// payloadPool returns the pool registered under name, calling fn to build it for the first VU only,
+// like k6's SharedArray. fn returns an array of strings, ArrayBuffers or typed arrays.
+func (mi *ModuleInstance) payloadPool(name string, fn sobek.Callable) *payloadPool {
+	rt := mi.vu.Runtime()
+	if mi.vu.State() != nil {
+		common.Throw(rt, fmt.Errorf("payload pools can only be created in the init context"))
+		return nil
+	}
+
+	r := mi.rootModule
+	r.payloadPoolsMu.Lock()
+	defer r.payloadPoolsMu.Unlock()
+
+	if pool, ok := r.payloadPools[name]; ok {
+		return pool
+	}
+
+	if fn == nil {
+		common.Throw(rt, fmt.Errorf("payload pool %q needs a function returning its payloads", name))
+		return nil
+	}
+	v, err := fn(sobek.Undefined())
+	if err != nil {
+		common.Throw(rt, err)
+		return nil
+	}
+	values, ok := v.Export().([]interface{})
+	if !ok || len(values) == 0 {
+		common.Throw(rt, fmt.Errorf("payload pool %q: expected a non-empty array, got %s", name, v.ExportType()))
+		return nil
+	}
+
+	pool := &payloadPool{Name: name, Size: len(values), payloads: make([][]byte, 0, len(values))}
+	for i, value := range values {
+		payload, err := payloadBytes(value)
+		if err != nil {
+			common.Throw(rt, fmt.Errorf("payload pool %q: payload %d: %w", name, i, err))
+			return nil
+		}
+		pool.payloads = append(pool.payloads, payload)
+	}
+
+	if r.payloadPools == nil {
+		r.payloadPools = make(map[string]*payloadPool)
+	}
+	r.payloadPools[name] = pool
+	return pool
+}
+
+// payloadBytes copies a JS payload, as the underlying buffer could change while blasts are in flight.
+func payloadBytes(v interface{}) ([]byte, error) {
+	switch data := v.(type) {
+	case string:
+		return []byte(data), nil
+	case []byte:
+		return append([]byte(nil), data...), nil
+	case sobek.ArrayBuffer:
+		return append([]byte(nil), data.Bytes()...), nil
+	default:
+		return nil, fmt.Errorf("expected a string, an ArrayBuffer or a Uint8Array, got %T", v)
+	}
+}
@@ -0,0 +1,68 @@
+package k6wrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.k6.io/k6/js/modulestest"
+)
+
+func TestPayloadPool(t *testing.T) {
+	t.Parallel()
+
+	root := New()
+	var pools []*payloadPool
+	for range 2 {
+		runtime := modulestest.NewRuntime(t)
+		mi, ok := root.NewModuleInstance(runtime.VU).(*ModuleInstance)
+		require.True(t, ok)
+		rt := runtime.VU.Runtime()
+		require.NoError(t, rt.Set("wrpc", mi.Exports().Default))
+		require.NoError(t, rt.Set("calls", 0))
+
+		v, err := rt.RunString(`wrpc.payloadPool("pool", () => {
+			calls++;
+			return ["text", new Uint8Array([1, 2, 3]), new Uint8Array([4, 5]).buffer];
+		})`)
+		require.NoError(t, err)
+		pool, ok := v.Export().(*payloadPool)
+		require.True(t, ok)
+		pools = append(pools, pool)
+
+		calls := rt.Get("calls").ToInteger()
+		if len(pools) == 1 {
+			assert.Equal(t, int64(1), calls)
+		} else {
+			assert.Zero(t, calls, "the pool is only built by the first VU")
+		}
+	}
+
+	assert.Same(t, pools[0], pools[1])
+	assert.Equal(t, 3, pools[0].Size)
+	assert.Equal(t, [][]byte{[]byte("text"), {1, 2, 3}, {4, 5}}, pools[0].payloads)
+}
+
+func TestPayloadPoolErrors(t *testing.T) {
+	t.Parallel()
+
+	testdata := map[string]struct{ expr, msg string }{
+		"empty":        {`wrpc.payloadPool("pool", () => [])`, "expected a non-empty array"},
+		"payload type": {`wrpc.payloadPool("pool", () => [1])`, "payload 0: expected a string, an ArrayBuffer or a Uint8Array"},
+		"throws":       {`wrpc.payloadPool("pool", () => { throw new Error("no such file") })`, "no such file"},
+	}
+	for name, data := range testdata {
+		data := data
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			runtime, mi := getTestModuleInstance(t)
+			rt := runtime.VU.Runtime()
+			require.NoError(t, rt.Set("wrpc", mi.Exports().Default))
+
+			_, err := rt.RunString(data.expr)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), data.msg)
+		})
+	}
+}
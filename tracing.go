@@ -0,0 +1,183 @@
+package k6wrpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.k6.io/k6/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	wrpc "wrpc.io/go"
+	wrpcnats "wrpc.io/go/nats"
+)
+
+// tracerName is the instrumentation scope of the client spans.
+const tracerName = "xk6-wrpc"
+
+// otlpShutdownTimeout bounds flushing the spans left when k6 exits.
+const otlpShutdownTimeout = 5 * time.Second
+
+// tracingOptions propagate the W3C trace context of every call, exporting the client spans if otlp is set.
+type tracingOptions struct {
+	OTLP *otlpOptions `json:"otlp,omitempty"`
+}
+
+type otlpOptions struct {
+	// OTLP/HTTP collector url, such as http://localhost:4318, the path defaults to /v1/traces
+	Endpoint string            `json:"endpoint"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	// service.name of the exported spans, defaults to k6
+	ServiceName string `json:"service_name,omitempty"`
+}
+
+// traceContext is the W3C trace context propagator, traceparent and tracestate.
+var traceContext = propagation.TraceContext{}
+
+// tracer returns the tracer of a client. Without tracing options it is a noop, so nothing is propagated.
+// Clients exporting to the same endpoint share a tracer provider, which is flushed when k6 exits.
+func (mi *ModuleInstance) tracer(options *tracingOptions) (trace.Tracer, error) {
+	if options == nil {
+		return noop.NewTracerProvider().Tracer(tracerName), nil
+	}
+	if options.OTLP == nil {
+		// spans still get ids to propagate, they just aren't exported
+		return sdktrace.NewTracerProvider().Tracer(tracerName), nil
+	}
+
+	o := options.OTLP
+	u, err := neturl.Parse(o.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, newParamsError("options.tracing.otlp.endpoint", "expected an http(s) url, got %q", o.Endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/v1/traces"
+	}
+	serviceName := o.ServiceName
+	if serviceName == "" {
+		serviceName = "k6"
+	}
+
+	r := mi.rootModule
+	r.tracerProvidersMu.Lock()
+	defer r.tracerProvidersMu.Unlock()
+
+	key := o.Endpoint + " " + serviceName
+	if tp, ok := r.tracerProviders[key]; ok {
+		return tp.Tracer(tracerName), nil
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(),
+		otlptracehttp.WithEndpointURL(u.String()),
+		otlptracehttp.WithHeaders(o.Headers),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+	if r.tracerProviders == nil {
+		r.tracerProviders = make(map[string]*sdktrace.TracerProvider)
+	}
+	r.tracerProviders[key] = tp
+	mi.shutdownOnExit(tp)
+
+	return tp.Tracer(tracerName), nil
+}
+
+// shutdownOnExit flushes the spans of tp before k6 exits.
+func (mi *ModuleInstance) shutdownOnExit(tp *sdktrace.TracerProvider) {
+	global := mi.vu.Events().Global
+	if global == nil {
+		return
+	}
+	logger := mi.vu.InitEnv().Logger
+	id, events := global.Subscribe(event.Exit)
+	go func() {
+		evt := <-events
+		defer evt.Done()
+		global.Unsubscribe(id)
+
+		ctx, done := context.WithTimeout(context.Background(), otlpShutdownTimeout)
+		defer done()
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.WithError(err).Warn("failed to flush wrpc spans")
+		}
+	}()
+}
+
+// withTraceContextHeader adds the trace context of ctx to the wRPC transport headers.
+func withTraceContextHeader(ctx context.Context) context.Context {
+	carrier := propagation.MapCarrier{}
+	traceContext.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return ctx
+	}
+
+	header := make(nats.Header)
+	if h, ok := wrpcnats.HeaderFromContext(ctx); ok {
+		for k, v := range h {
+			header[k] = v
+		}
+	}
+	for k, v := range carrier {
+		header[k] = []string{v}
+	}
+	return wrpcnats.ContextWithHeader(ctx, header)
+}
+
+// withTraceContext adds the trace context of ctx to HTTP request headers, unless the script set it.
+func withTraceContext(ctx context.Context, headers []*wrpc.Tuple2[string, [][]uint8]) []*wrpc.Tuple2[string, [][]uint8] {
+	carrier := propagation.MapCarrier{}
+	traceContext.Inject(ctx, carrier)
+	if len(carrier) == 0 || hasHeader(headers, "traceparent") {
+		return headers
+	}
+
+	ret := make([]*wrpc.Tuple2[string, [][]uint8], 0, len(headers)+len(carrier))
+	ret = append(ret, headers...)
+	for _, k := range carrier.Keys() {
+		ret = append(ret, &wrpc.Tuple2[string, [][]uint8]{
+			V0: k,
+			V1: [][]uint8{[]byte(carrier.Get(k))},
+		})
+	}
+	return ret
+}
+
+// startHTTPSpan starts the client span of one hop of an http request.
+func startHTTPSpan(ctx context.Context, tracer trace.Tracer, method string, u *neturl.URL) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "HTTP "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", method),
+			attribute.String("url.full", u.Redacted()),
+		),
+	)
+}
+
+func endHTTPSpan(span trace.Span, resp *httpResponse, err error) {
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case resp.Status >= http.StatusInternalServerError:
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.Status))
+		span.SetStatus(codes.Error, strconv.Itoa(resp.Status))
+	default:
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.Status))
+	}
+	span.End()
+}
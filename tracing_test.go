@@ -0,0 +1,116 @@
+package k6wrpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	wrpc "wrpc.io/go"
+	wrpcnats "wrpc.io/go/nats"
+)
+
+func testSpanContext(t *testing.T) context.Context {
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+}
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestWithTraceContext(t *testing.T) {
+	t.Parallel()
+
+	headers := []*wrpc.Tuple2[string, [][]uint8]{{V0: "Accept", V1: [][]uint8{[]byte("text/plain")}}}
+
+	assert.Equal(t, headers, withTraceContext(context.Background(), headers))
+
+	traced := withTraceContext(testSpanContext(t), headers)
+	require.Len(t, traced, 2)
+	assert.Equal(t, "traceparent", traced[1].V0)
+	assert.Equal(t, testTraceparent, string(traced[1].V1[0]))
+	assert.Len(t, headers, 1)
+
+	// a traceparent set by the script wins
+	own := append(headers, &wrpc.Tuple2[string, [][]uint8]{V0: "Traceparent", V1: [][]uint8{[]byte("x")}})
+	assert.Equal(t, own, withTraceContext(testSpanContext(t), own))
+}
+
+func TestWithTraceContextHeader(t *testing.T) {
+	t.Parallel()
+
+	ctx := withTraceContextHeader(context.Background())
+	_, ok := wrpcnats.HeaderFromContext(ctx)
+	assert.False(t, ok)
+
+	ctx = wrpcnats.ContextWithHeader(testSpanContext(t), map[string][]string{"x-scenario": {"test"}})
+	header, ok := wrpcnats.HeaderFromContext(withTraceContextHeader(ctx))
+	require.True(t, ok)
+	assert.Equal(t, []string{testTraceparent}, header["traceparent"])
+	assert.Equal(t, []string{"test"}, header["x-scenario"])
+}
+
+func TestTracerOTLP(t *testing.T) {
+	t.Parallel()
+
+	// a collector stand-in, counting the exported batches
+	var exports atomic.Int64
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		if r.Method == http.MethodPost && r.URL.Path == "/v1/traces" {
+			exports.Add(1)
+		}
+	}))
+	defer collector.Close()
+
+	_, mi := getTestModuleInstance(t)
+	options := &tracingOptions{OTLP: &otlpOptions{Endpoint: collector.URL}}
+	tracer, err := mi.tracer(options)
+	require.NoError(t, err)
+
+	// clients share the provider of an endpoint
+	_, err = mi.tracer(options)
+	require.NoError(t, err)
+	require.Len(t, mi.rootModule.tracerProviders, 1)
+
+	_, span := tracer.Start(context.Background(), "xk6:wrpc/blaster@0.0.1/blast")
+	assert.True(t, span.SpanContext().IsValid())
+	span.End()
+
+	for _, tp := range mi.rootModule.tracerProviders {
+		require.NoError(t, tp.Shutdown(context.Background()))
+	}
+	assert.Equal(t, int64(1), exports.Load())
+}
+
+func TestTracerOptions(t *testing.T) {
+	t.Parallel()
+
+	_, mi := getTestModuleInstance(t)
+
+	tracer, err := mi.tracer(nil)
+	require.NoError(t, err)
+	_, span := tracer.Start(context.Background(), "noop")
+	assert.False(t, span.SpanContext().IsValid())
+
+	tracer, err = mi.tracer(&tracingOptions{})
+	require.NoError(t, err)
+	_, span = tracer.Start(context.Background(), "propagated")
+	assert.True(t, span.SpanContext().IsValid())
+
+	_, err = mi.tracer(&tracingOptions{OTLP: &otlpOptions{Endpoint: "localhost:4318"}})
+	var pErr *paramsError
+	require.ErrorAs(t, err, &pErr)
+	assert.Equal(t, "options.tracing.otlp.endpoint", pErr.Path)
+}
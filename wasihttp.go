@@ -8,9 +8,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log/slog"
 	"net/http"
-	"os"
+	neturl "net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -22,6 +24,7 @@ import (
 	"go.k6.io/k6/js/modules"
 	"go.k6.io/k6/lib/netext/httpext"
 	"go.k6.io/k6/metrics"
+	"go.opentelemetry.io/otel/trace"
 	wrpc "wrpc.io/go"
 )
 
@@ -30,34 +33,49 @@ var ErrRPC = errors.New("rpc error")
 // default timeout in ms
 var DefaultHTTPTimeout = int64(30 * 1000)
 
+// default number of redirects to follow
+var DefaultHTTPRedirects = int64(10)
+
 type wasiHTTP struct {
 	vu               modules.VU
 	obj              *sobek.Object
 	metrics          *wrpcMetrics
 	tags             map[string]string
 	invoker          wrpc.Invoker
+	handle           func(context.Context, wrpc.Invoker, *wrpctypes.Request) (*wrpc.Result[wrpctypes.Response, wrpctypes.ErrorCode], <-chan error, error)
+	tracer           trace.Tracer
+	debug            *debugger
+	redirects        int64
 	responseCallback func(int) bool
 }
 
 func newWasiHTTP(vu modules.VU, wm *wrpcMetrics, options clientOptions) (*wasiHTTP, error) {
 	rt := vu.Runtime()
 
-	driver, err := newNatsDriver(vu, wm, options.NATS, options.Tags)
+	driver, err := newNatsDriver(vu, wm, options)
 	if err != nil {
 		return nil, err
 	}
 
 	w := &wasiHTTP{
-		vu:      vu,
-		metrics: wm,
-		tags:    options.Tags,
-		obj:     rt.NewObject(),
-		invoker: driver.nc,
+		vu:        vu,
+		metrics:   wm,
+		tags:      options.Tags,
+		obj:       rt.NewObject(),
+		invoker:   driver.invoker,
+		handle:    incoming_handler.Handle,
+		tracer:    options.tracer,
+		debug:     options.debugger,
+		redirects: DefaultHTTPRedirects,
 		responseCallback: func(status int) bool {
 			return status <= 200 && status < 300
 		},
 	}
 
+	if options.Redirects != nil {
+		w.redirects = *options.Redirects
+	}
+
 	if err := w.obj.Set("get", w.noBodyRequest(http.MethodGet)); err != nil {
 		return nil, err
 	}
@@ -80,12 +98,16 @@ func newWasiHTTP(vu modules.VU, wm *wrpcMetrics, options clientOptions) (*wasiHT
 	if err := w.obj.Set("put", w.bodyRequest(http.MethodPut)); err != nil {
 		return nil, err
 	}
+	if err := w.obj.Set("url", taggedURL); err != nil {
+		return nil, err
+	}
 
 	return w, nil
 }
 
 type httpResponse struct {
 	Status  int
+	URL     string
 	Headers map[string][]string
 	Body    []byte
 }
@@ -114,24 +136,26 @@ func (w wasiTrailer) Close() error {
 	return nil
 }
 
-func jsBodyToWrpc(body interface{}) (io.ReadCloser, error) {
+// jsBodyToWrpc buffers the request body so it can be replayed when following redirects.
+// Objects are form encoded unless jsonBody is set, returning the matching content type.
+func jsBodyToWrpc(body interface{}, jsonBody bool) ([]byte, string, error) {
 	switch data := body.(type) {
 	case string:
-		return io.NopCloser(bytes.NewBufferString(data)), nil
+		return []byte(data), "", nil
 	case []byte:
-		return io.NopCloser(bytes.NewBuffer(data)), nil
+		return data, "", nil
 	case sobek.ArrayBuffer:
-		return io.NopCloser(bytes.NewBuffer(data.Bytes())), nil
+		return data.Bytes(), "", nil
 	case map[string]interface{}:
-		d, err := json.Marshal(data)
-		if err != nil {
-			return nil, err
+		if jsonBody {
+			d, err := json.Marshal(data)
+			return d, contentTypeJSON, err
 		}
-		return io.NopCloser(bytes.NewBuffer(d)), nil
+		return encodeObjectBody(data)
 	case nil:
-		return http.NoBody, nil
+		return nil, "", nil
 	default:
-		return nil, fmt.Errorf("unsupported body type %T", body)
+		return nil, "", fmt.Errorf("unsupported body type %T", body)
 	}
 }
 
@@ -142,14 +166,11 @@ func (w *wasiHTTP) request(method string, url sobek.Value, args ...sobek.Value)
 	} else {
 		tagSet = state.Tags.GetCurrentValues().Tags.WithTagsFromMap(w.tags)
 	}
-	timeout := DefaultHTTPTimeout
+	timeout := time.Duration(DefaultHTTPTimeout) * time.Millisecond
+	redirects := w.redirects
 	consumeBody := false
-
-	measurements := make([]metrics.Sample, 0)
-	defer func() {
-		w.metrics.pushIfNotDone(w.vu, measurements...)
-	}()
-	reqStart := time.Now()
+	jsonBody := false
+	compression := ""
 
 	parsedURL, err := httpext.ToURL(url.Export())
 	if err != nil {
@@ -159,105 +180,260 @@ func (w *wasiHTTP) request(method string, url sobek.Value, args ...sobek.Value)
 
 	headers := make([]*wrpc.Tuple2[string, [][]uint8], 0)
 
-	var body io.ReadCloser
-
-	var trailers wrpc.Receiver[[]*wrpc.Tuple2[string, [][]uint8]]
-	trailers = wasiTrailer{}
-
 	bodyParam, params := splitRequestArgs(args)
-	body, err = jsBodyToWrpc(bodyParam.Export())
-	if err != nil {
-		return nil, err
-	}
 
 	if params != nil {
-		p := params.Export().(map[string]interface{})
+		rt := w.vu.Runtime()
+		p := newParamsDecoder(rt, "params", params)
 
 		// auth
-		if data, ok := p["auth"]; ok {
-			d := data.(map[string]interface{})
-
-			if user, ok := d["username"]; ok {
-				if pass, ok := d["password"]; ok {
-					headers = append(headers, &wrpc.Tuple2[string, [][]uint8]{
-						V0: "Authorization",
-						V1: [][]uint8{
-							[]byte(
-								fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", user, pass))))),
-						},
-					})
-				}
+		if auth := p.Object("auth"); auth != nil {
+			var user, pass string
+			auth.String("username", &user)
+			auth.String("password", &pass)
+			if user != "" || pass != "" {
+				headers = append(headers, &wrpc.Tuple2[string, [][]uint8]{
+					V0: "Authorization",
+					V1: [][]uint8{
+						[]byte(
+							fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", user, pass))))),
+					},
+				})
 			}
 
 			// TODO(lxf): bearer tokens
 		}
 
-		// timeout in ms
-		if data, ok := p["timeout"]; ok {
-			timeout = data.(int64)
-		}
+		// k6 duration string or ms
+		p.Duration("timeout", &timeout)
 
 		// if we should read the body or not
-		if data, ok := p["consume"]; ok {
-			consumeBody = data.(bool)
-		}
+		p.Bool("consume", &consumeBody)
+
+		// max redirects to follow
+		p.Int("redirects", &redirects)
+
+		// metric tags
+		tags := make(map[string]string)
+		p.StringMap("tags", tags)
+		tagSet = tagSet.WithTagsFromMap(tags)
+
+		// request body compression
+		p.String("compression", &compression)
+
+		// encode object bodies as json instead of forms
+		p.Bool("json", &jsonBody)
+
+		// query string params
+		var query map[string]interface{}
+		p.Map("query", &query)
+		u = withQuery(u, query)
 
 		// headers
-		if data, ok := p["headers"]; ok {
-			h := data.(map[string]interface{})
-			for k, v := range h {
-				vs := v.(string)
-				headers = append(headers, &wrpc.Tuple2[string, [][]uint8]{
-					V0: k,
-					V1: [][]uint8{[]byte(vs)},
-				})
-			}
+		if v := p.Value("headers"); v != nil {
+			h, err := jsHeadersToWrpc(rt, v)
+			p.Check("headers", err)
+			headers = append(headers, h...)
 		}
 
+		// k6-http params without a wRPC equivalent
+		p.Unsupported("cookies")
+		p.Unsupported("jar")
+
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// all redirect hops are grouped under the name of the original request
+	if _, ok := tagSet.Get(metrics.TagName.String()); !ok && w.vu.State().Options.SystemTags.Has(metrics.TagName) {
+		tagSet = tagSet.With(metrics.TagName.String(), parsedURL.Name)
+	}
+
+	body, contentType, err := jsBodyToWrpc(bodyParam.Export(), jsonBody)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" && !hasHeader(headers, "Content-Type") {
+		headers = append(headers, &wrpc.Tuple2[string, [][]uint8]{
+			V0: "Content-Type",
+			V1: [][]uint8{[]byte(contentType)},
+		})
+	}
+
+	if compression != "" && len(body) > 0 {
+		var contentEncoding string
+		body, contentEncoding, err = compressBody(compression, body)
+		if err != nil {
+			return nil, newParamsError("params.compression", "%s", err)
+		}
+		headers = append(headers, &wrpc.Tuple2[string, [][]uint8]{
+			V0: "Content-Encoding",
+			V1: [][]uint8{[]byte(contentEncoding)},
+		})
+	}
+
+	// the timeout covers the whole redirect chain
+	ctx, done := context.WithTimeout(w.vu.Context(), timeout)
+	defer done()
+
+	for hop := int64(0); ; hop++ {
+		hopCtx, span := startHTTPSpan(ctx, w.tracer, method, u)
+		resp, err := w.roundTrip(hopCtx, method, u, headers, body, consumeBody, tagSet)
+		endHTTPSpan(span, resp, err)
+		w.debug.dumpHTTP(method, u, withTraceContext(hopCtx, headers), body, resp, err)
+		if err != nil {
+			return nil, err
+		}
+
+		location := http.Header(resp.Headers).Get("Location")
+		if !isRedirect(resp.Status) || location == "" || hop >= redirects {
+			return resp, nil
+		}
+
+		next, err := u.Parse(location)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redirect location %q: %w", location, err)
+		}
+
+		method, body, headers = redirectRequest(resp.Status, method, body, headers)
+		if next.Host != u.Host {
+			// don't leak credentials to other hosts
+			headers = withoutHeaders(headers, "Authorization", "Cookie")
+		}
+		u = next
+	}
+}
+
+// roundTrip performs a single wRPC request, without following redirects.
+func (w *wasiHTTP) roundTrip(
+	ctx context.Context,
+	method string,
+	u *neturl.URL,
+	headers []*wrpc.Tuple2[string, [][]uint8],
+	body []byte,
+	consumeBody bool,
+	tagSet *metrics.TagSet,
+) (*httpResponse, error) {
+	var sent bool
+	measurements := make([]metrics.Sample, 0)
+	defer func() {
+		// requests are counted once the response is in, so they carry its status
+		if sent {
+			measurements = append(measurements,
+				w.metrics.sample(w.metrics.httpRequest, 1, tagSet),
+				w.metrics.sample(w.metrics.httpRequestSize, float64(len(body)), tagSet),
+			)
+		}
+		w.metrics.pushIfNotDone(w.vu, measurements...)
+	}()
+	reqStart := time.Now()
+
+	hopURL, err := httpext.NewURL(u.String(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	systemTags := w.vu.State().Options.SystemTags
+	if systemTags.Has(metrics.TagMethod) {
+		tagSet = tagSet.With(metrics.TagMethod.String(), method)
+	}
+	if systemTags.Has(metrics.TagURL) {
+		tagSet = tagSet.With(metrics.TagURL.String(), hopURL.Clean())
+	}
+	// k6 has no scheme system tag to opt out of, it only ever holds http or https
+	tagSet = tagSet.With("scheme", hopURL.GetURL().Scheme)
+
+	var trailers wrpc.Receiver[[]*wrpc.Tuple2[string, [][]uint8]]
+	trailers = wasiTrailer{}
+
+	var reqBody io.ReadCloser = http.NoBody
+	if body != nil {
+		reqBody = io.NopCloser(bytes.NewReader(body))
 	}
 
 	pathWithQuery := u.RequestURI()
 	authority := u.Host
 
 	wreq := &wrpctypes.Request{
-		Headers:       headers,
+		Headers:       withTraceContext(ctx, headers),
 		Method:        HttpMethodToWrpc(method),
 		Scheme:        HttpSchemeToWrpc(u.Scheme),
 		PathWithQuery: &pathWithQuery,
 		Authority:     &authority,
-		Body:          body,
+		Body:          reqBody,
 		// TODO(lxf): implement trailers?
 		Trailers: trailers,
 	}
 
-	ctx, done := context.WithTimeout(w.vu.Context(), time.Duration(timeout)*time.Millisecond)
-	defer done()
-
-	measurements = append(measurements, w.metrics.sample(w.metrics.httpRequest, 1, tagSet))
-
-	res, _, err := incoming_handler.Handle(ctx, w.invoker, wreq)
+	sent = true
+	ctx = withInvocationScope(ctx, invocationScope{ctx: w.vu.Context(), samples: w.vu.State().Samples, tagSet: tagSet})
+	res, writeErrs, err := w.handle(ctx, w.invoker, wreq)
 	if err != nil {
 		measurements = append(measurements, w.metrics.sample(w.metrics.transportError, 1, tagSet))
 		return nil, err
 	}
 
+	// the body and trailers are streamed along with the response, a server answering
+	// doesn't mean it got all of them
+	checkWrites := func() error {
+		if err := drainWriteErrs(writeErrs); err != nil {
+			measurements = append(measurements, w.metrics.sample(w.metrics.writeError, 1, tagSet))
+			return fmt.Errorf("failed to write request body: %w", err)
+		}
+		return nil
+	}
+
 	if res.Err != nil {
 		measurements = append(measurements, w.metrics.sample(w.metrics.httpError, 1, tagSet))
+		if err := checkWrites(); err != nil {
+			return nil, errors.Join(res.Err, err)
+		}
 		return nil, res.Err
 	}
 
 	resp := res.Ok
 
+	if systemTags.Has(metrics.TagStatus) {
+		tagSet = tagSet.With(metrics.TagStatus.String(), strconv.Itoa(int(resp.Status)))
+	}
+
+	incomingHeaders := make(http.Header)
+	for _, header := range resp.Headers {
+		for _, v := range header.V1 {
+			incomingHeaders.Add(header.V0, string(v))
+		}
+	}
+
 	var incomingBody []byte
 	if consumeBody {
+		wireBody := &countingReader{r: resp.Body}
+		decodedBody, err := decompressBody(incomingHeaders.Get("Content-Encoding"), wireBody)
+		if err != nil {
+			resp.Body.Close()
+			return nil, errors.Join(err, checkWrites())
+		}
 		bodyReader := bytes.NewBuffer(incomingBody)
-		if _, err := io.Copy(bodyReader, resp.Body); err != nil {
-			return nil, err
+		_, err = io.Copy(bodyReader, decodedBody)
+		// releases the decoders, zstd ones hold goroutines until closed
+		decodedBody.Close()
+		if err != nil {
+			resp.Body.Close()
+			return nil, errors.Join(err, checkWrites())
 		}
 		incomingBody = bodyReader.Bytes()
+
+		measurements = append(measurements,
+			w.metrics.sample(w.metrics.httpResponseWireSize, float64(wireBody.n), tagSet),
+			w.metrics.sample(w.metrics.httpResponseSize, float64(len(incomingBody)), tagSet),
+		)
 	}
 	resp.Body.Close()
 
+	if err := checkWrites(); err != nil {
+		return nil, err
+	}
+
 	reqDuration := time.Since(reqStart)
 	measurements = append(measurements, w.metrics.sample(w.metrics.httpDuration, metrics.D(reqDuration), tagSet))
 
@@ -270,29 +446,60 @@ func (w *wasiHTTP) request(method string, url sobek.Value, args ...sobek.Value)
 		measurements = append(measurements, w.metrics.sample(w.metrics.httpInvalidResponse, 1, tagSet))
 	}
 
-	incomingHeaders := make(http.Header)
-	for _, header := range resp.Headers {
-		for _, v := range header.V1 {
-			incomingHeaders.Add(header.V0, string(v))
-		}
-	}
-
 	return &httpResponse{
 		Status:  int(resp.Status),
+		URL:     u.String(),
 		Headers: incomingHeaders,
 		Body:    incomingBody,
 	}, nil
 }
 
-func xinit() {
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-		Level: slog.LevelDebug, ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-			if a.Key == slog.TimeKey {
-				return slog.Attr{}
-			}
-			return a
-		},
-	})))
+func isRedirect(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	default:
+		return false
+	}
+}
+
+// redirectRequest rewrites the method and body of a request following a redirect,
+// using the same rules as net/http: 301, 302 and 303 turn into a bodyless GET
+// (HEAD is preserved), while 307 and 308 replay the original method and body.
+func redirectRequest(
+	status int,
+	method string,
+	body []byte,
+	headers []*wrpc.Tuple2[string, [][]uint8],
+) (string, []byte, []*wrpc.Tuple2[string, [][]uint8]) {
+	switch status {
+	case http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return method, body, headers
+	}
+
+	if method != http.MethodHead {
+		method = http.MethodGet
+	}
+	return method, nil, withoutHeaders(headers, "Content-Type", "Content-Length", "Content-Encoding")
+}
+
+func hasHeader(headers []*wrpc.Tuple2[string, [][]uint8], name string) bool {
+	return slices.ContainsFunc(headers, func(header *wrpc.Tuple2[string, [][]uint8]) bool {
+		return strings.EqualFold(name, header.V0)
+	})
+}
+
+func withoutHeaders(headers []*wrpc.Tuple2[string, [][]uint8], names ...string) []*wrpc.Tuple2[string, [][]uint8] {
+	ret := make([]*wrpc.Tuple2[string, [][]uint8], 0, len(headers))
+	for _, header := range headers {
+		if !slices.ContainsFunc(names, func(name string) bool {
+			return strings.EqualFold(name, header.V0)
+		}) {
+			ret = append(ret, header)
+		}
+	}
+	return ret
 }
 
 func splitRequestArgs(args []sobek.Value) (body sobek.Value, params sobek.Value) {
@@ -0,0 +1,102 @@
+package k6wrpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.k6.io/k6/metrics"
+	"go.opentelemetry.io/otel/trace/noop"
+	wrpc "wrpc.io/go"
+
+	wrpctypes "xk6-wrpc/internal/wrpc/http/types"
+)
+
+func TestRequestTags(t *testing.T) {
+	t.Parallel()
+
+	runtime, wm, samples := getTestVU(t, 1)
+	w := &wasiHTTP{
+		vu:      runtime.VU,
+		metrics: wm,
+		tags:    map[string]string{"client": "a"},
+		tracer:  noop.NewTracerProvider().Tracer(tracerName),
+		handle: func(context.Context, wrpc.Invoker, *wrpctypes.Request) (*wrpc.Result[wrpctypes.Response, wrpctypes.ErrorCode], <-chan error, error) {
+			return wrpc.Ok[wrpctypes.ErrorCode](wrpctypes.Response{
+				Status: http.StatusCreated,
+				Body:   io.NopCloser(strings.NewReader("created")),
+			}), nil, nil
+		},
+		responseCallback: func(int) bool { return true },
+	}
+
+	u, err := runtime.VU.Runtime().RunString("const id = 1; http.url`https://localhost/posts/${id}`")
+	require.NoError(t, err)
+	params, err := runtime.VU.Runtime().RunString(`({consume: true, tags: {request: "b"}})`)
+	require.NoError(t, err)
+	_, err = w.request(http.MethodPost, u, runtime.VU.Runtime().ToValue("hello"), params)
+	require.NoError(t, err)
+
+	expected := map[string]string{
+		"client":  "a",
+		"request": "b",
+		"method":  http.MethodPost,
+		"status":  "201",
+		"scheme":  "https",
+		"url":     "https://localhost/posts/1",
+		"name":    "https://localhost/posts/${}",
+	}
+	seen := make(map[string]bool)
+	for _, container := range metrics.GetBufferedSamples(samples) {
+		for _, sample := range container.GetSamples() {
+			seen[sample.Metric.Name] = true
+			assert.Equal(t, expected, sample.Tags.Map(), sample.Metric.Name)
+		}
+	}
+	for _, name := range []string{metricHTTPRequest, metricHTTPRequestSize, metricHTTPResponseSize, metricHTTPDuration} {
+		assert.True(t, seen[name], name)
+	}
+}
+
+func TestRedirectRequest(t *testing.T) {
+	t.Parallel()
+
+	headers := []*wrpc.Tuple2[string, [][]uint8]{
+		{V0: "Content-Type", V1: [][]uint8{[]byte("application/json")}},
+		{V0: "X-Header", V1: [][]uint8{[]byte("value")}},
+	}
+	body := []byte(`{"hello":"world"}`)
+
+	testdata := map[string]struct {
+		status     int
+		method     string
+		wantMethod string
+		keepBody   bool
+	}{
+		"301 post":   {http.StatusMovedPermanently, http.MethodPost, http.MethodGet, false},
+		"302 put":    {http.StatusFound, http.MethodPut, http.MethodGet, false},
+		"303 post":   {http.StatusSeeOther, http.MethodPost, http.MethodGet, false},
+		"303 head":   {http.StatusSeeOther, http.MethodHead, http.MethodHead, false},
+		"307 post":   {http.StatusTemporaryRedirect, http.MethodPost, http.MethodPost, true},
+		"308 delete": {http.StatusPermanentRedirect, http.MethodDelete, http.MethodDelete, true},
+	}
+	for name, data := range testdata {
+		data := data
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			method, newBody, newHeaders := redirectRequest(data.status, data.method, body, headers)
+			assert.Equal(t, data.wantMethod, method)
+			if data.keepBody {
+				assert.Equal(t, body, newBody)
+				assert.Equal(t, headers, newHeaders)
+			} else {
+				assert.Nil(t, newBody)
+				assert.Equal(t, headers[1:], newHeaders)
+			}
+		})
+	}
+}